@@ -0,0 +1,59 @@
+package bone
+
+import (
+	"encoding/binary"
+)
+
+// The Append functions write the encoding of a single primitive to dst
+// without building a Value tree. They produce the same bytes as encoding the
+// matching New function's result.
+
+func AppendInt(dst []byte, i int64) []byte {
+	if i >= 0 && i < 8 {
+		return append(dst, 0x10+byte(i))
+	}
+	n := intWidth(i)
+	if i < 0 {
+		dst = append(dst, 0x10-byte(n))
+	} else {
+		dst = append(dst, 0x17+byte(n))
+	}
+	for s := 8 * (n - 1); s >= 0; s -= 8 {
+		dst = append(dst, byte(i>>s))
+	}
+	return dst
+}
+
+func AppendBool(dst []byte, b bool) []byte {
+	if b {
+		return append(dst, CodeTrue)
+	}
+	return append(dst, CodeFalse)
+}
+
+func AppendFloat(dst []byte, f float64) []byte {
+	dst = append(dst, CodeFloat)
+	return binary.BigEndian.AppendUint64(dst, floatBits(f))
+}
+
+func AppendText(dst []byte, s string) []byte {
+	dst = append(dst, CodeText)
+	for i := 0; i < len(s); i++ {
+		dst = append(dst, s[i])
+		if s[i] == 0x00 {
+			dst = append(dst, 0x01)
+		}
+	}
+	return append(dst, 0x00)
+}
+
+func AppendBytes(dst []byte, b []byte) []byte {
+	dst = append(dst, CodeBytes)
+	for _, c := range b {
+		dst = append(dst, c)
+		if c == 0x00 {
+			dst = append(dst, 0x01)
+		}
+	}
+	return append(dst, 0x00)
+}
@@ -0,0 +1,94 @@
+package bone
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestAppendMatchesEncode(t *testing.T) {
+	cases := []struct {
+		got  []byte
+		want *Value
+	}{
+		{AppendInt(nil, -300), NewInt(-300)},
+		{AppendInt(nil, 5), NewInt(5)},
+		{AppendInt(nil, math.MaxInt64), NewInt(math.MaxInt64)},
+		{AppendBool(nil, true), &Value{Code: CodeTrue}},
+		{AppendBool(nil, false), &Value{Code: CodeFalse}},
+		{AppendFloat(nil, -0.5), NewFloat(-0.5)},
+		{AppendText(nil, "a\x00b"), NewText("a\x00b")},
+		{AppendBytes(nil, []byte{0x00, 0x00}), NewBytes([]byte{0x00, 0x00})},
+	}
+	for _, tc := range cases {
+		if want := Encode([]*Value{tc.want}); !bytes.Equal(tc.got, want) {
+			t.Errorf("Expected % X, got % X", want, tc.got)
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	payload := AppendInt(nil, -70000)
+	payload = AppendBool(payload, true)
+	payload = AppendFloat(payload, 1.25)
+	payload = AppendText(payload, "x\x00")
+	payload = AppendBytes(payload, []byte{0xFF})
+
+	i, n, err := ReadInt(payload)
+	if err != nil || i != -70000 {
+		t.Fatalf("Expected -70000, got %d, %v", i, err)
+	}
+	payload = payload[n:]
+	b, n, err := ReadBool(payload)
+	if err != nil || !b {
+		t.Fatalf("Expected true, got %v, %v", b, err)
+	}
+	payload = payload[n:]
+	f, n, err := ReadFloat(payload)
+	if err != nil || f != 1.25 {
+		t.Fatalf("Expected 1.25, got %v, %v", f, err)
+	}
+	payload = payload[n:]
+	s, n, err := ReadText(payload)
+	if err != nil || s != "x\x00" {
+		t.Fatalf("Expected %q, got %q, %v", "x\x00", s, err)
+	}
+	payload = payload[n:]
+	raw, n, err := ReadBytes(payload)
+	if err != nil || !bytes.Equal(raw, []byte{0xFF}) {
+		t.Fatalf("Expected FF, got % X, %v", raw, err)
+	}
+	if n != len(payload) {
+		t.Errorf("Expected to consume the remaining %d bytes, got %d", len(payload), n)
+	}
+
+	for _, bad := range [][]byte{{}, {0x18}, {0x20}, {0x91, 0x41}, {0x70, 0x00}} {
+		if _, _, err := ReadInt(bad); err == nil {
+			t.Errorf("Expected ReadInt error for % X", bad)
+		}
+		if _, _, err := ReadText(bad); err == nil {
+			t.Errorf("Expected ReadText error for % X", bad)
+		}
+		if _, _, err := ReadFloat(bad); err == nil {
+			t.Errorf("Expected ReadFloat error for % X", bad)
+		}
+	}
+}
+
+func TestReadBytesInto(t *testing.T) {
+	enc := AppendBytes(nil, []byte{0x00, 0x01, 0x00})
+	buf := make([]byte, 0, 8)
+	got, n, err := ReadBytesInto(buf, enc)
+	if err != nil || n != len(enc) || !bytes.Equal(got, []byte{0x00, 0x01, 0x00}) {
+		t.Fatalf("Expected 00 01 00, got % X, %d, %v", got, n, err)
+	}
+	if &got[0] != &buf[:1][0] {
+		t.Error("Expected the bytes to be appended into dst")
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		ReadBytesInto(buf, enc)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
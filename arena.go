@@ -0,0 +1,90 @@
+package bone
+
+// Arena allocates Value nodes, byte payloads and child slices for a Decoder in
+// large chunks, so decoding millions of small values costs a handful of
+// allocations instead of several per value. Reset recycles every chunk, which
+// invalidates all values decoded since the previous Reset.
+type Arena struct {
+	values    [][]Value
+	valuesIdx int
+	valuesOff int
+	bytes     [][]byte
+	bytesIdx  int
+	ptrs      [][]*Value
+	ptrsIdx   int
+}
+
+const (
+	arenaValues = 1024
+	arenaBytes  = 64 << 10
+	arenaPtrs   = 4096
+)
+
+func (a *Arena) newValue() *Value {
+	if a.valuesIdx == len(a.values) {
+		a.values = append(a.values, make([]Value, arenaValues))
+	}
+	v := &a.values[a.valuesIdx][a.valuesOff]
+	*v = Value{}
+	a.valuesOff++
+	if a.valuesOff == arenaValues {
+		a.valuesIdx++
+		a.valuesOff = 0
+	}
+	return v
+}
+
+// allocBytes returns an empty slice with capacity n that can be appended to
+// without reallocating.
+func (a *Arena) allocBytes(n int) []byte {
+	if n > arenaBytes/4 {
+		return make([]byte, 0, n)
+	}
+	for a.bytesIdx < len(a.bytes) {
+		c := a.bytes[a.bytesIdx]
+		if cap(c)-len(c) >= n {
+			a.bytes[a.bytesIdx] = c[:len(c)+n]
+			return c[len(c) : len(c) : len(c)+n]
+		}
+		a.bytesIdx++
+	}
+	a.bytes = append(a.bytes, make([]byte, n, arenaBytes))
+	return a.bytes[a.bytesIdx][0:0:n]
+}
+
+func (a *Arena) copyBytes(b []byte) []byte {
+	return append(a.allocBytes(len(b)), b...)
+}
+
+func (a *Arena) allocValues(n int) []*Value {
+	if n > arenaPtrs/4 {
+		return make([]*Value, 0, n)
+	}
+	for a.ptrsIdx < len(a.ptrs) {
+		c := a.ptrs[a.ptrsIdx]
+		if cap(c)-len(c) >= n {
+			a.ptrs[a.ptrsIdx] = c[:len(c)+n]
+			return c[len(c) : len(c) : len(c)+n]
+		}
+		a.ptrsIdx++
+	}
+	a.ptrs = append(a.ptrs, make([]*Value, n, arenaPtrs))
+	return a.ptrs[a.ptrsIdx][0:0:n]
+}
+
+func (a *Arena) copyValues(values []*Value) []*Value {
+	return append(a.allocValues(len(values)), values...)
+}
+
+func (a *Arena) Reset() {
+	a.valuesIdx, a.valuesOff = 0, 0
+	for i := range a.bytes {
+		a.bytes[i] = a.bytes[i][:0]
+	}
+	a.bytesIdx = 0
+	for i := range a.ptrs {
+		clear(a.ptrs[i])
+		a.ptrs[i] = a.ptrs[i][:0]
+	}
+	a.ptrsIdx = 0
+}
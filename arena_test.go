@@ -0,0 +1,83 @@
+package bone
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func decodeWith(d *Decoder, payload []byte) ([]*Value, error) {
+	d.Reset()
+	return decode(d, payload)
+}
+
+func TestArenaDecode(t *testing.T) {
+	d := &Decoder{Arena: &Arena{}}
+	for round := range 3 {
+		for i, data := range DecodableSeedCorpus {
+			t.Run(fmt.Sprintf("round %d DecodableSeedCorpus[%d]", round, i), func(t *testing.T) {
+				values, err := decodeWith(d, data)
+				if err != nil {
+					t.Fatalf("Failed to decode: %v", err)
+				}
+				if got := Encode(values); !bytes.Equal(got, data) {
+					t.Errorf("Expected % X, got % X", data, got)
+				}
+			})
+		}
+	}
+}
+
+func TestArenaDecodeChunked(t *testing.T) {
+	var payload []byte
+	for _, data := range DecodableSeedCorpus {
+		payload = append(payload, data...)
+	}
+	d := &Decoder{Arena: &Arena{}}
+	for i := 0; i < len(payload); i += 3 {
+		if _, err := d.Write(payload[i:min(i+3, len(payload))]); err != nil {
+			t.Fatalf("Failed to decode: %v", err)
+		}
+	}
+	if err := d.TerminateString(0xFF); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	if got := Encode(d.Values); !bytes.Equal(got, payload) {
+		t.Errorf("Expected chunked arena decode to round trip")
+	}
+}
+
+func TestArenaStrict(t *testing.T) {
+	d := &Decoder{Arena: &Arena{}, Strict: true}
+	if _, err := decodeWith(d, []byte{0xF0, 0x91, 0xFF, 0x00, 0x00}); err == nil {
+		t.Errorf("Expected invalid utf-8 error")
+	}
+	if _, err := decodeWith(d, []byte{0xF2, 0x12, 0x11, 0x00}); err == nil {
+		t.Errorf("Expected unsorted set error")
+	}
+	if _, err := decodeWith(d, []byte{0xF2, 0x11, 0x91, 0x41, 0x00, 0x00}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestArenaAllocations(t *testing.T) {
+	var payload []byte
+	for range 50 {
+		for _, data := range DecodableSeedCorpus {
+			payload = append(payload, data...)
+		}
+	}
+	d := &Decoder{Arena: &Arena{}}
+	decodeWith(d, payload)
+	allocs := testing.AllocsPerRun(20, func() {
+		if _, err := decodeWith(d, payload); err != nil {
+			t.Fatal(err)
+		}
+	})
+	heap := testing.AllocsPerRun(20, func() {
+		Decode(payload)
+	})
+	if allocs > heap/100 {
+		t.Errorf("Expected arena decoding to allocate far less than %v times per run, got %v", heap, allocs)
+	}
+}
@@ -0,0 +1,101 @@
+package bone_test
+
+import (
+	"testing"
+
+	"github.com/mrmcc3/bone-go"
+	"github.com/mrmcc3/bone-go/internal/benchdata"
+)
+
+const benchValues = 1000
+
+func BenchmarkEncode(b *testing.B) {
+	for _, shape := range benchdata.Shapes {
+		values := shape.Generate(benchValues)
+		b.Run(shape.Name, func(b *testing.B) {
+			b.SetBytes(int64(len(bone.Encode(values))))
+			b.ReportAllocs()
+			for b.Loop() {
+				bone.Encode(values)
+			}
+		})
+	}
+}
+
+func BenchmarkAppendEncode(b *testing.B) {
+	for _, shape := range benchdata.Shapes {
+		values := shape.Generate(benchValues)
+		buf := bone.Encode(values)
+		b.Run(shape.Name, func(b *testing.B) {
+			b.SetBytes(int64(len(buf)))
+			b.ReportAllocs()
+			for b.Loop() {
+				buf = buf[:0]
+				for _, v := range values {
+					buf = bone.AppendEncode(buf, v)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkDecode compares the table driven decoder with the legacy one.
+func BenchmarkDecode(b *testing.B) {
+	decoders := []struct {
+		name   string
+		decode func([]byte) ([]*bone.Value, error)
+	}{
+		{"legacy", bone.LegacyDecode},
+		{"table", bone.Decode},
+	}
+	for _, shape := range benchdata.Shapes {
+		payload := bone.Encode(shape.Generate(benchValues))
+		for _, d := range decoders {
+			b.Run(shape.Name+"/"+d.name, func(b *testing.B) {
+				b.SetBytes(int64(len(payload)))
+				b.ReportAllocs()
+				for b.Loop() {
+					if _, err := d.decode(payload); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestBenchdataRoundTrip(t *testing.T) {
+	for _, shape := range benchdata.Shapes {
+		payload := bone.Encode(shape.Generate(10))
+		values, err := bone.Decode(payload)
+		if err != nil {
+			t.Fatalf("%s: failed to decode: %v", shape.Name, err)
+		}
+		if len(values) != 10 {
+			t.Errorf("%s: expected 10 values, got %d", shape.Name, len(values))
+		}
+		if again := bone.Encode(shape.Generate(10)); string(again) != string(payload) {
+			t.Errorf("%s: generator is not reproducible", shape.Name)
+		}
+	}
+}
+
+func BenchmarkDecodeArena(b *testing.B) {
+	for _, shape := range benchdata.Shapes {
+		payload := bone.Encode(shape.Generate(benchValues))
+		b.Run(shape.Name, func(b *testing.B) {
+			d := &bone.Decoder{Arena: &bone.Arena{}}
+			b.SetBytes(int64(len(payload)))
+			b.ReportAllocs()
+			for b.Loop() {
+				d.Reset()
+				if _, err := d.Write(payload); err != nil {
+					b.Fatal(err)
+				}
+				if err := d.TerminateString(0xFF); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package bone
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// NewBigInt encodes x as a T2 of its signed byte length and its magnitude as a
+// raw string. Negative magnitudes are complemented so that BigInt values sort
+// in numeric order against each other. They do not interleave with NewInt
+// values, which all sort before the T2 code, so keys that must order
+// numerically should use one of the two encodings throughout.
+func NewBigInt(x *big.Int) *Value {
+	mag := x.Bytes()
+	n := int64(len(mag))
+	if x.Sign() < 0 {
+		n = -n
+		complement(mag)
+	}
+	return &Value{Code: CodeBigInt, Values: []*Value{
+		NewInt(n),
+		NewBytes(mag),
+	}}
+}
+
+func (v *Value) BigInt() (*big.Int, error) {
+	if v.Code != CodeBigInt {
+		i, err := v.Int()
+		if err != nil {
+			return nil, errors.New("not a big int")
+		}
+		return big.NewInt(i), nil
+	}
+	if len(v.Values) != 2 || !v.Values[1].String() {
+		return nil, errors.New("malformed big int")
+	}
+	n, err := v.Values[0].Int()
+	if err != nil {
+		return nil, err
+	}
+	mag := bytes.Clone(v.Values[1].Bytes)
+	neg := n < 0
+	if neg {
+		n = -n
+		complement(mag)
+	}
+	if int64(len(mag)) != n || (n > 0 && mag[0] == 0x00) {
+		return nil, errors.New("malformed big int")
+	}
+	x := new(big.Int).SetBytes(mag)
+	if neg {
+		if n == 0 {
+			return nil, errors.New("malformed big int")
+		}
+		x.Neg(x)
+	}
+	return x, nil
+}
+
+// NewDecimal encodes mant * 10^exp as a T3 of sign, adjusted exponent and
+// significant digits. The representation is normalized so equal amounts encode
+// identically (1.50 and 1.5 are the same value) and Decimal values sort in
+// numeric order against each other.
+func NewDecimal(mant *big.Int, exp int) *Value {
+	if mant.Sign() == 0 {
+		return &Value{Code: CodeDecimal, Values: []*Value{
+			NewInt(0),
+			NewInt(0),
+			NewBytes(nil),
+		}}
+	}
+	s := new(big.Int).Abs(mant).String()
+	t := strings.TrimRight(s, "0")
+	exp += len(s) - len(t)
+	adj := int64(exp + len(t) - 1)
+	digits := []byte(t)
+	if mant.Sign() < 0 {
+		for i, d := range digits {
+			digits[i] = '0' + '9' - d
+		}
+		digits = append(digits, 0xFF)
+		return &Value{Code: CodeDecimal, Values: []*Value{
+			NewInt(-1),
+			NewInt(-adj),
+			NewBytes(digits),
+		}}
+	}
+	return &Value{Code: CodeDecimal, Values: []*Value{
+		NewInt(1),
+		NewInt(adj),
+		NewBytes(digits),
+	}}
+}
+
+func (v *Value) Decimal() (*big.Int, int, error) {
+	if v.Code != CodeDecimal {
+		x, err := v.BigInt()
+		if err != nil {
+			return nil, 0, errors.New("not a decimal")
+		}
+		return x, 0, nil
+	}
+	if len(v.Values) != 3 || !v.Values[2].String() {
+		return nil, 0, errors.New("malformed decimal")
+	}
+	sign, err := v.Values[0].Int()
+	if err != nil {
+		return nil, 0, err
+	}
+	adj, err := v.Values[1].Int()
+	if err != nil {
+		return nil, 0, err
+	}
+	digits := bytes.Clone(v.Values[2].Bytes)
+	switch sign {
+	case 0:
+		if adj != 0 || len(digits) != 0 {
+			return nil, 0, errors.New("malformed decimal")
+		}
+		return new(big.Int), 0, nil
+	case -1:
+		if len(digits) == 0 || digits[len(digits)-1] != 0xFF {
+			return nil, 0, errors.New("malformed decimal")
+		}
+		digits = digits[:len(digits)-1]
+		for i, d := range digits {
+			digits[i] = '0' + '9' - d
+		}
+		adj = -adj
+	case 1:
+	default:
+		return nil, 0, errors.New("malformed decimal")
+	}
+	if len(digits) == 0 || digits[0] == '0' || digits[len(digits)-1] == '0' {
+		return nil, 0, errors.New("malformed decimal")
+	}
+	for _, d := range digits {
+		if d < '0' || d > '9' {
+			return nil, 0, errors.New("malformed decimal")
+		}
+	}
+	mant, _ := new(big.Int).SetString(string(digits), 10)
+	if sign < 0 {
+		mant.Neg(mant)
+	}
+	return mant, int(adj) - len(digits) + 1, nil
+}
+
+func complement(b []byte) {
+	for i := range b {
+		b[i] = ^b[i]
+	}
+}
@@ -0,0 +1,120 @@
+package bone
+
+import (
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestBigInt(t *testing.T) {
+	var xs []*big.Int
+	for _, s := range []string{
+		"-340282366920938463463374607431768211456",
+		"-18446744073709551617",
+		"-18446744073709551616",
+		"-256",
+		"-255",
+		"-1",
+		"0",
+		"1",
+		"255",
+		"256",
+		"18446744073709551616",
+		"340282366920938463463374607431768211456",
+	} {
+		x, _ := new(big.Int).SetString(s, 10)
+		xs = append(xs, x)
+	}
+	var encoded [][]byte
+	for _, x := range xs {
+		payload := Encode([]*Value{NewBigInt(x)})
+		values, err := Decode(payload)
+		if err != nil {
+			t.Fatalf("Failed to decode %s: %v", x, err)
+		}
+		got, err := values[0].BigInt()
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", x, err)
+		}
+		if got.Cmp(x) != 0 {
+			t.Errorf("Expected %s, got %s", x, got)
+		}
+		encoded = append(encoded, payload)
+	}
+	if !slices.IsSortedFunc(encoded, slices.Compare) {
+		t.Errorf("Encoded big ints are not in numeric order")
+	}
+	for i := 1; i < len(xs); i++ {
+		if Compare(NewBigInt(xs[i-1]), NewBigInt(xs[i])) >= 0 {
+			t.Errorf("Expected %s to compare below %s", xs[i-1], xs[i])
+		}
+	}
+	got, err := NewInt(-86).BigInt()
+	if err != nil || got.Int64() != -86 {
+		t.Errorf("Expected block int to read as big int -86, got %v, %v", got, err)
+	}
+	// Big ints and block ints do not interleave: every big int sorts after
+	// every block int, whatever their values.
+	huge, _ := new(big.Int).SetString("-100000000000000000000", 10)
+	if Compare(NewBigInt(huge), NewInt(0)) != 1 || Compare(NewBigInt(big.NewInt(-1)), NewInt(math.MaxInt64)) != 1 {
+		t.Errorf("Expected big ints to sort after block ints")
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	decimals := []struct {
+		mant string
+		exp  int
+	}{
+		{"-1000", 3},
+		{"-123", 0},
+		{"-123", -2},
+		{"-12", -1},
+		{"-12", -2},
+		{"-5", -3},
+		{"0", 0},
+		{"5", -3},
+		{"12", -2},
+		{"12", -1},
+		{"123", -2},
+		{"150", -2},
+		{"123", 0},
+		{"1", 6},
+	}
+	var encoded [][]byte
+	for _, d := range decimals {
+		mant, _ := new(big.Int).SetString(d.mant, 10)
+		payload := Encode([]*Value{NewDecimal(mant, d.exp)})
+		values, err := Decode(payload)
+		if err != nil {
+			t.Fatalf("Failed to decode %se%d: %v", d.mant, d.exp, err)
+		}
+		gotMant, gotExp, err := values[0].Decimal()
+		if err != nil {
+			t.Fatalf("Failed to read %se%d: %v", d.mant, d.exp, err)
+		}
+		want := new(big.Rat).SetInt(mant)
+		got := new(big.Rat).SetInt(gotMant)
+		scale := func(r *big.Rat, exp int) {
+			p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(max(exp, -exp))), nil)
+			if exp < 0 {
+				r.Quo(r, new(big.Rat).SetInt(p))
+			} else {
+				r.Mul(r, new(big.Rat).SetInt(p))
+			}
+		}
+		scale(want, d.exp)
+		scale(got, gotExp)
+		if want.Cmp(got) != 0 {
+			t.Errorf("Expected %se%d, got %se%d", d.mant, d.exp, gotMant, gotExp)
+		}
+		encoded = append(encoded, payload)
+	}
+	if !slices.IsSortedFunc(encoded, slices.Compare) {
+		t.Errorf("Encoded decimals are not in numeric order")
+	}
+	if Compare(NewDecimal(big.NewInt(150), -2), NewDecimal(big.NewInt(15), -1)) != 0 {
+		t.Errorf("Expected 1.50 and 1.5 to encode identically")
+	}
+}
@@ -0,0 +1,134 @@
+// Package bloom implements bloom filters over BONE values, hashing their
+// canonical encodings so equal values always hit the same bits.
+//
+// A filter can also hold prefix keys: with Prefix set to N, adding a tuple or
+// list of at least N values also adds its first N values, which HasPrefix
+// then tests. This answers "might any key start with these components"
+// without a scan.
+//
+// A filter serializes to the BONE value (k, prefix, bits), a T3 tuple of two
+// ints and a byte string. The bits cannot be a block since blocks hold at
+// most 16 bytes.
+package bloom
+
+import (
+	"errors"
+	"hash/fnv"
+	"math"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+type Filter struct {
+	// Prefix is the number of leading tuple or list values added as a prefix
+	// key. It must be set before the first Add; zero disables prefix keys.
+	Prefix int
+
+	k    int
+	bits []byte
+}
+
+// maxBytes bounds the size of a filter so that its bit count fits the
+// uint32 arithmetic of the hashes.
+const maxBytes = math.MaxUint32 / 8
+
+// New returns a filter sized for n keys at false positive rate p. Each
+// value added with a prefix key counts as two keys. It panics unless p is
+// strictly between 0 and 1 or if the filter would exceed 512 MiB.
+func New(n int, p float64) *Filter {
+	if !(p > 0 && p < 1) {
+		panic("bloom: false positive rate must be between 0 and 1")
+	}
+	n = max(n, 1)
+	bits := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	if bits > maxBytes*8 {
+		panic("bloom: filter exceeds 512 MiB")
+	}
+	m := max(int(bits), 8)
+	k := int(math.Round(float64(m) / float64(n) * math.Ln2))
+	return &Filter{k: max(k, 1), bits: make([]byte, (m+7)/8)}
+}
+
+func hash(b []byte) (uint32, uint32) {
+	h := fnv.New64a()
+	h.Write(b)
+	x := h.Sum64()
+	return uint32(x), uint32(x>>32) | 1
+}
+
+func (f *Filter) add(key []byte) {
+	h1, h2 := hash(key)
+	m := uint32(len(f.bits) * 8)
+	for i := range uint32(f.k) {
+		b := (h1 + i*h2) % m
+		f.bits[b/8] |= 1 << (b % 8)
+	}
+}
+
+func (f *Filter) has(key []byte) bool {
+	h1, h2 := hash(key)
+	m := uint32(len(f.bits) * 8)
+	for i := range uint32(f.k) {
+		b := (h1 + i*h2) % m
+		if f.bits[b/8]&(1<<(b%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// prefixKey returns the hashed form of a prefix: 0x00, which never starts an
+// encoded value, followed by the encodings of the values.
+func prefixKey(values []*bone.Value) []byte {
+	key := []byte{0x00}
+	for _, v := range values {
+		key = bone.AppendEncode(key, v)
+	}
+	return key
+}
+
+func (f *Filter) Add(v *bone.Value) {
+	f.add(bone.AppendEncode(nil, v))
+	if f.Prefix > 0 && v.Code >= 0xA0 && v.Code != 0xFF && len(v.Values) >= f.Prefix {
+		f.add(prefixKey(v.Values[:f.Prefix]))
+	}
+}
+
+// Has reports whether v may have been added. False positives occur at the
+// configured rate; false negatives never.
+func (f *Filter) Has(v *bone.Value) bool {
+	return f.has(bone.AppendEncode(nil, v))
+}
+
+// HasPrefix reports whether a tuple or list starting with values may have
+// been added. It panics unless len(values) is the filter's Prefix.
+func (f *Filter) HasPrefix(values ...*bone.Value) bool {
+	if f.Prefix == 0 || len(values) != f.Prefix {
+		panic("bloom: prefix length does not match filter")
+	}
+	return f.has(prefixKey(values))
+}
+
+// Value returns the serialized filter.
+func (f *Filter) Value() *bone.Value {
+	return bone.Tuple(bone.Int(int64(f.k)), bone.Int(int64(f.Prefix)), bone.Raw(f.bits))
+}
+
+// FromValue restores a filter serialized with Value.
+func FromValue(v *bone.Value) (*Filter, error) {
+	if v.Code != 0xC0 || v.Level != 0 || len(v.Values) != 3 || v.Values[2].Code != bone.CodeBytes {
+		return nil, errors.New("bloom: not a serialized filter")
+	}
+	k, err := v.Values[0].Int()
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := v.Values[1].Int()
+	if err != nil {
+		return nil, err
+	}
+	if k < 1 || k > 64 || prefix < 0 || len(v.Values[2].Bytes) == 0 || len(v.Values[2].Bytes) > maxBytes {
+		return nil, errors.New("bloom: invalid filter parameters")
+	}
+	return &Filter{Prefix: int(prefix), k: int(k), bits: v.Values[2].Bytes}, nil
+}
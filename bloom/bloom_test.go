@@ -0,0 +1,95 @@
+package bloom
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+func key(tenant string, i int) *bone.Value {
+	return bone.Tuple(bone.Str(tenant), bone.Int(int64(i)), bone.Null)
+}
+
+func TestFilter(t *testing.T) {
+	f := New(2000, 0.01)
+	f.Prefix = 1
+	for i := range 1000 {
+		f.Add(key("acme", i))
+	}
+	for i := range 1000 {
+		if !f.Has(key("acme", i)) {
+			t.Fatalf("Expected key %d to be present", i)
+		}
+	}
+	if !f.HasPrefix(bone.Str("acme")) {
+		t.Error("Expected prefix to be present")
+	}
+	positives := 0
+	for i := range 10000 {
+		if f.Has(key("globex", i)) {
+			positives++
+		}
+	}
+	if positives > 300 {
+		t.Errorf("Expected about 1%% false positives, got %d in 10000", positives)
+	}
+	misses := 0
+	for i := range 1000 {
+		if !f.HasPrefix(bone.Int(int64(i))) {
+			misses++
+		}
+	}
+	if misses < 900 {
+		t.Errorf("Expected most absent prefixes to miss, got %d misses", misses)
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	f := New(100, 0.05)
+	f.Prefix = 2
+	f.Add(key("acme", 1))
+	values, err := bone.Decode(bone.Encode([]*bone.Value{f.Value()}))
+	if err != nil {
+		t.Fatalf("Failed to decode filter: %v", err)
+	}
+	g, err := FromValue(values[0])
+	if err != nil {
+		t.Fatalf("Failed to restore filter: %v", err)
+	}
+	if !g.Has(key("acme", 1)) || !g.HasPrefix(bone.Str("acme"), bone.Int(1)) {
+		t.Error("Expected restored filter to hold the key and its prefix")
+	}
+	if g.Prefix != 2 || g.k != f.k {
+		t.Errorf("Expected prefix 2 and k %d, got %d and %d", f.k, g.Prefix, g.k)
+	}
+	for _, v := range []*bone.Value{
+		bone.Int(1),
+		bone.Tuple(bone.Int(0), bone.Int(0), bone.Raw([]byte{1})),
+		bone.Tuple(bone.Int(1), bone.Int(0), bone.Int(1)),
+	} {
+		if _, err := FromValue(v); err == nil {
+			t.Errorf("Expected error for % X", bone.Encode([]*bone.Value{v}))
+		}
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	cases := map[string]func(){
+		"zero rate":     func() { New(100, 0) },
+		"negative rate": func() { New(100, -0.5) },
+		"rate of one":   func() { New(100, 1) },
+		"NaN rate":      func() { New(100, math.NaN()) },
+		"too large":     func() { New(math.MaxInt32, 1e-9) },
+	}
+	for name, build := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic")
+				}
+			}()
+			build()
+		})
+	}
+}
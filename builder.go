@@ -0,0 +1,102 @@
+package bone
+
+import "fmt"
+
+// The builder functions construct Values with correct codes, levels and
+// payloads, as a shorthand for spelling out Value literals. They panic on
+// arity, width or level violations, so they suit fixtures and static data
+// rather than untrusted input.
+
+// True, False and Null are shared by every user of the package and must not
+// be modified. Use Bool, or Level for a level extended copy, to get a value of
+// one's own.
+var (
+	True  = &Value{Code: CodeTrue}
+	False = &Value{Code: CodeFalse}
+	Null  = &Value{Code: CodeNull}
+)
+
+// Bool returns a new true or false value.
+func Bool(b bool) *Value {
+	if b {
+		return &Value{Code: CodeTrue}
+	}
+	return &Value{Code: CodeFalse}
+}
+
+func Int(i int64) *Value {
+	return NewInt(i)
+}
+
+func Float(f float64) *Value {
+	return NewFloat(f)
+}
+
+func Str(s string) *Value {
+	return NewText(s)
+}
+
+func Raw(b []byte) *Value {
+	return NewBytes(b)
+}
+
+// Block returns a block value, checking that len(b) matches the width code
+// implies.
+func Block(code byte, b ...byte) *Value {
+	v := &Value{Code: code, Bytes: b}
+	if !v.Block() {
+		panic(fmt.Sprintf("bone: 0x%02X is not a block type code", code))
+	}
+	if len(b) == 0 {
+		v.Bytes = nil
+	}
+	if !v.Complete() {
+		panic(fmt.Sprintf("bone: block 0x%02X cannot hold %d bytes", code, len(b)))
+	}
+	return v
+}
+
+func Tuple(values ...*Value) *Value {
+	if len(values) < 1 || len(values) > 5 {
+		panic(fmt.Sprintf("bone: tuples hold 1 to 5 values, got %d", len(values)))
+	}
+	return TupleOf(0xA0+byte(len(values)-1)*0x10, values...)
+}
+
+func TupleOf(code byte, values ...*Value) *Value {
+	v := &Value{Code: code, Values: values}
+	if code < 0xA0 || code >= 0xF0 {
+		panic(fmt.Sprintf("bone: 0x%02X is not a tuple type code", code))
+	}
+	if !v.Complete() {
+		panic(fmt.Sprintf("bone: tuple 0x%02X cannot hold %d values", code, len(values)))
+	}
+	return v
+}
+
+func List(values ...*Value) *Value {
+	return ListOf(CodeList, values...)
+}
+
+func ListOf(code byte, values ...*Value) *Value {
+	if code < 0xF0 || code == 0xFF {
+		panic(fmt.Sprintf("bone: 0x%02X is not a list type code", code))
+	}
+	if values == nil {
+		values = []*Value{}
+	}
+	return &Value{Code: code, Values: values}
+}
+
+// Level returns a copy of v with the given level extension.
+func Level(level int, v *Value) *Value {
+	if level < 0 {
+		panic("bone: negative level")
+	}
+	if level > 0 && v.Code < 0x20 {
+		panic(fmt.Sprintf("bone: 0x%02X cannot be level extended", v.Code))
+	}
+	c := *v
+	c.Level = level
+	return &c
+}
@@ -0,0 +1,81 @@
+package bone
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	v := List(Str("ABC"), Tuple(True, Int(-86)), Level(2, Bool(true)))
+	expected := []byte{
+		0xF0,
+		0x91, 0x41, 0x42, 0x43, 0x00,
+		0xB0, 0x21, 0x0F, 0xAA,
+		0xFF, 0xFF, 0x21,
+		0x00,
+	}
+	if got := Encode([]*Value{v}); !bytes.Equal(got, expected) {
+		t.Errorf("Expected % X, got % X", expected, got)
+	}
+	if True.Level != 0 {
+		t.Errorf("Expected Level not to modify its argument")
+	}
+	Bool(true).Level = 1
+	if b := Bool(true); b == True || b.Level != 0 || True.Level != 0 {
+		t.Errorf("Expected Bool to return a fresh value")
+	}
+
+	nested := List(
+		Str("\x00"),
+		TupleOf(0xA1, TupleOf(0xB1,
+			ListOf(0xF1, Block(0x10)),
+			Level(1, TupleOf(0xAF, Level(1, &Value{Code: 0x9F, Bytes: []byte{0xFF}}))),
+		)),
+		Level(2, ListOf(0xFE)),
+	)
+	payload := []byte{
+		0xF0,
+		0x91, 0x00, 0x01, 0x00,
+		0xA1,
+		0xB1,
+		0xF1, 0x10, 0x00,
+		0xFF, 0xAF, 0xFF, 0x9F, 0xFF, 0x00,
+		0xFF, 0xFF, 0xFE, 0x00,
+		0x00,
+	}
+	if got := Encode([]*Value{nested}); !bytes.Equal(got, payload) {
+		t.Errorf("Expected % X, got % X", payload, got)
+	}
+	if got := Encode([]*Value{Block(0x40, 0xBB, 0xCC), Float(1), Null, Raw([]byte{0x00})}); !bytes.Equal(got, []byte{
+		0x40, 0xBB, 0xCC,
+		0x70, 0xBF, 0xF0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x22,
+		0x90, 0x00, 0x01, 0x00,
+	}) {
+		t.Errorf("Unexpected encoding % X", got)
+	}
+}
+
+func TestBuilderPanics(t *testing.T) {
+	cases := map[string]func(){
+		"empty tuple":     func() { Tuple() },
+		"wide tuple":      func() { Tuple(True, True, True, True, True, True) },
+		"tuple arity":     func() { TupleOf(0xB0, True) },
+		"tuple code":      func() { TupleOf(0xF0, True) },
+		"list code":       func() { ListOf(0xB0) },
+		"block code":      func() { Block(0x90) },
+		"block width":     func() { Block(0x40, 0x01) },
+		"negative level":  func() { Level(-1, True) },
+		"level extension": func() { Level(1, Int(300)) },
+	}
+	for name, build := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic")
+				}
+			}()
+			build()
+		})
+	}
+}
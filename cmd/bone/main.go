@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mrmcc3/bone-go"
+	"github.com/mrmcc3/bone-go/sort"
+)
+
+const usage = `usage: bone <command>
+
+commands:
+  from-json   convert JSON values on stdin to BONE on stdout
+  to-json     convert BONE values on stdin to JSON lines on stdout
+  sort        sort BONE values on stdin by encoding to stdout
+              flags: -m bytes (memory budget), -u (drop duplicates),
+              -T dir (temporary directory)
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "from-json":
+		err = fromJSON(os.Stdin, os.Stdout)
+	case "to-json":
+		err = toJSON(os.Stdin, os.Stdout)
+	case "sort":
+		err = sortValues(os.Args[2:], os.Stdin, os.Stdout)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "bone:", err)
+		os.Exit(1)
+	}
+}
+
+func fromJSON(r io.Reader, w io.Writer) error {
+	dec := json.NewDecoder(r)
+	out := bufio.NewWriter(w)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		v, err := bone.FromJSON(bytes.NewReader(raw))
+		if err != nil {
+			return err
+		}
+		out.Write(bone.Encode([]*bone.Value{v}))
+	}
+	return out.Flush()
+}
+
+func toJSON(r io.Reader, w io.Writer) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	values, err := bone.Decode(data)
+	if err != nil {
+		return err
+	}
+	out := bufio.NewWriter(w)
+	for _, v := range values {
+		if err := bone.ToJSON(v, out); err != nil {
+			return err
+		}
+		out.WriteByte('\n')
+	}
+	return out.Flush()
+}
+
+func sortValues(args []string, r io.Reader, w io.Writer) error {
+	var opts sort.Options
+	flags := flag.NewFlagSet("sort", flag.ExitOnError)
+	flags.IntVar(&opts.MemoryBudget, "m", sort.DefaultMemoryBudget, "memory budget in bytes")
+	flags.BoolVar(&opts.Dedup, "u", false, "drop duplicate values")
+	flags.StringVar(&opts.TempDir, "T", "", "directory for temporary files")
+	flags.Parse(args)
+	return sort.Sort(w, r, opts)
+}
@@ -0,0 +1,23 @@
+package example
+
+//go:generate go run github.com/mrmcc3/bone-go/cmd/bonegen
+
+//bone:generate
+type Key struct {
+	Tenant string
+	User   int64
+	TS     int64
+}
+
+//bone:generate code=0xF3
+type Record struct {
+	Key     Key
+	Name    string
+	Active  bool
+	Score   float64
+	Age     uint8
+	Payload []byte
+	Tags    []string
+	Related []Key
+	cache   string `bone:"-"`
+}
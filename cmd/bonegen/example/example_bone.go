@@ -0,0 +1,180 @@
+// Code generated by bonegen. DO NOT EDIT.
+
+package example
+
+import (
+	"errors"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+func (x *Key) AppendBONE(dst []byte) []byte {
+	dst = append(dst, 0xC0)
+	dst = bone.AppendText(dst, x.Tenant)
+	dst = bone.AppendInt(dst, x.User)
+	dst = bone.AppendInt(dst, x.TS)
+	return dst
+}
+
+func (x *Key) DecodeBONE(src []byte) (int, error) {
+	n, err := bone.ReadCode(src, 0xC0)
+	if err != nil {
+		return 0, err
+	}
+	{
+		v, m, err := bone.ReadText(src[n:])
+		if err != nil {
+			return n, err
+		}
+		x.Tenant = v
+		n += m
+	}
+	{
+		v, m, err := bone.ReadInt(src[n:])
+		if err != nil {
+			return n, err
+		}
+		x.User = v
+		n += m
+	}
+	{
+		v, m, err := bone.ReadInt(src[n:])
+		if err != nil {
+			return n, err
+		}
+		x.TS = v
+		n += m
+	}
+	return n, nil
+}
+
+func (x *Record) AppendBONE(dst []byte) []byte {
+	dst = append(dst, 0xF3)
+	dst = x.Key.AppendBONE(dst)
+	dst = bone.AppendText(dst, x.Name)
+	dst = bone.AppendBool(dst, x.Active)
+	dst = bone.AppendFloat(dst, x.Score)
+	dst = bone.AppendInt(dst, int64(x.Age))
+	dst = bone.AppendBytes(dst, x.Payload)
+	dst = append(dst, 0xF0)
+	for i0 := range x.Tags {
+		dst = bone.AppendText(dst, x.Tags[i0])
+	}
+	dst = append(dst, 0x00)
+	dst = append(dst, 0xF0)
+	for i0 := range x.Related {
+		dst = x.Related[i0].AppendBONE(dst)
+	}
+	dst = append(dst, 0x00)
+	dst = append(dst, 0x00)
+	return dst
+}
+
+func (x *Record) DecodeBONE(src []byte) (int, error) {
+	n, err := bone.ReadCode(src, 0xF3)
+	if err != nil {
+		return 0, err
+	}
+	{
+		m, err := x.Key.DecodeBONE(src[n:])
+		if err != nil {
+			return n, err
+		}
+		n += m
+	}
+	{
+		v, m, err := bone.ReadText(src[n:])
+		if err != nil {
+			return n, err
+		}
+		x.Name = v
+		n += m
+	}
+	{
+		v, m, err := bone.ReadBool(src[n:])
+		if err != nil {
+			return n, err
+		}
+		x.Active = v
+		n += m
+	}
+	{
+		v, m, err := bone.ReadFloat(src[n:])
+		if err != nil {
+			return n, err
+		}
+		x.Score = v
+		n += m
+	}
+	{
+		v, m, err := bone.ReadInt(src[n:])
+		if err != nil {
+			return n, err
+		}
+		if int64(uint8(v)) != v {
+			return n, errors.New("int overflow")
+		}
+		x.Age = uint8(v)
+		n += m
+	}
+	{
+		v, m, err := bone.ReadBytesInto(x.Payload[:0], src[n:])
+		if err != nil {
+			return n, err
+		}
+		x.Payload = v
+		n += m
+	}
+	{
+		m, err := bone.ReadCode(src[n:], 0xF0)
+		if err != nil {
+			return n, err
+		}
+		n += m
+		x.Tags = x.Tags[:0]
+		for n < len(src) && src[n] != 0x00 {
+			var e0 string
+			{
+				v, m, err := bone.ReadText(src[n:])
+				if err != nil {
+					return n, err
+				}
+				e0 = v
+				n += m
+			}
+			x.Tags = append(x.Tags, e0)
+		}
+		if n >= len(src) {
+			return n, errors.New("unterminated list")
+		}
+		n++
+	}
+	{
+		m, err := bone.ReadCode(src[n:], 0xF0)
+		if err != nil {
+			return n, err
+		}
+		n += m
+		x.Related = x.Related[:0]
+		for n < len(src) && src[n] != 0x00 {
+			var e0 Key
+			{
+				m, err := e0.DecodeBONE(src[n:])
+				if err != nil {
+					return n, err
+				}
+				n += m
+			}
+			x.Related = append(x.Related, e0)
+		}
+		if n >= len(src) {
+			return n, errors.New("unterminated list")
+		}
+		n++
+	}
+	if n >= len(src) || src[n] != 0x00 {
+		return n, errors.New("expected list terminator")
+	}
+	n++
+	return n, nil
+}
@@ -0,0 +1,107 @@
+package example
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+func testRecord() *Record {
+	return &Record{
+		Key:     Key{Tenant: "acme", User: 42, TS: 1_700_000_000},
+		Name:    "a\x00b",
+		Active:  true,
+		Score:   2.5,
+		Age:     200,
+		Payload: []byte{0x00, 0xFF},
+		Tags:    []string{"x", "y"},
+		Related: []Key{{Tenant: "b", User: -1, TS: 0}},
+	}
+}
+
+func TestRecordMatchesEncode(t *testing.T) {
+	r := testRecord()
+	key := func(k Key) *bone.Value {
+		return &bone.Value{Code: 0xC0, Values: []*bone.Value{bone.NewText(k.Tenant), bone.NewInt(k.User), bone.NewInt(k.TS)}}
+	}
+	expected := bone.Encode([]*bone.Value{{
+		Code: 0xF3,
+		Values: []*bone.Value{
+			key(r.Key),
+			bone.NewText(r.Name),
+			{Code: bone.CodeTrue},
+			bone.NewFloat(r.Score),
+			bone.NewInt(int64(r.Age)),
+			bone.NewBytes(r.Payload),
+			{Code: 0xF0, Values: []*bone.Value{bone.NewText("x"), bone.NewText("y")}},
+			{Code: 0xF0, Values: []*bone.Value{key(r.Related[0])}},
+		},
+	}})
+	got := r.AppendBONE(nil)
+	if !bytes.Equal(got, expected) {
+		t.Fatalf("Expected % X, got % X", expected, got)
+	}
+
+	var decoded Record
+	n, err := decoded.DecodeBONE(append(got, 0x20))
+	if err != nil {
+		t.Fatalf("Failed to decode record: %v", err)
+	}
+	if n != len(got) {
+		t.Errorf("Expected to consume %d bytes, got %d", len(got), n)
+	}
+	if !reflect.DeepEqual(&decoded, r) {
+		t.Errorf("Expected %+v, got %+v", r, &decoded)
+	}
+}
+
+func TestRecordDecodeErrors(t *testing.T) {
+	payload := testRecord().AppendBONE(nil)
+	for i := range len(payload) {
+		var r Record
+		if _, err := r.DecodeBONE(payload[:i]); err == nil {
+			t.Errorf("Expected error decoding %d byte prefix", i)
+		}
+	}
+	var r Record
+	k := (&Key{}).AppendBONE(nil)
+	bad := append([]byte{0xF3}, k...)
+	bad = bone.AppendText(bad, "")
+	bad = bone.AppendBool(bad, false)
+	bad = bone.AppendFloat(bad, 0)
+	bad = bone.AppendInt(bad, 256)
+	if _, err := r.DecodeBONE(bad); err == nil {
+		t.Errorf("Expected int overflow error for uint8 field")
+	}
+}
+
+func TestAppendAllocations(t *testing.T) {
+	r := testRecord()
+	buf := make([]byte, 0, 256)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = r.AppendBONE(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+func TestDecodeAllocations(t *testing.T) {
+	src := testRecord().AppendBONE(nil)
+	var r Record
+	if _, err := r.DecodeBONE(src); err != nil {
+		t.Fatal(err)
+	}
+	// Decoding into a used Record reuses its slices, leaving at most one
+	// allocation for each of its five strings.
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := r.DecodeBONE(src); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > 5 {
+		t.Errorf("Expected at most 5 allocations, got %v", allocs)
+	}
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const directive = "//bone:generate"
+
+type structInfo struct {
+	name   string
+	code   byte
+	fields []field
+}
+
+type field struct {
+	name string
+	typ  fieldType
+}
+
+type fieldType struct {
+	kind string
+	elem *fieldType
+}
+
+var scalars = map[string]string{
+	"bool":    "bool",
+	"int":     "int",
+	"int8":    "int",
+	"int16":   "int",
+	"int32":   "int",
+	"int64":   "int",
+	"uint8":   "int",
+	"byte":    "int",
+	"uint16":  "int",
+	"uint32":  "int",
+	"float64": "float",
+	"string":  "text",
+}
+
+// Generate returns the formatted source of the codecs for every annotated
+// struct in the Go file src.
+func Generate(filename string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	var structs []*structInfo
+	names := map[string]bool{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			doc := ts.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			args, ok := findDirective(doc)
+			if !ok {
+				continue
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				return nil, fmt.Errorf("%s: %s is not a struct", fset.Position(ts.Pos()), ts.Name.Name)
+			}
+			info := &structInfo{name: ts.Name.Name}
+			if err := info.parseArgs(args); err != nil {
+				return nil, fmt.Errorf("%s: %v", fset.Position(ts.Pos()), err)
+			}
+			for _, f := range st.Fields.List {
+				if f.Tag != nil {
+					tag, _ := strconv.Unquote(f.Tag.Value)
+					if reflect.StructTag(tag).Get("bone") == "-" {
+						continue
+					}
+				}
+				ft, err := parseType(f.Type)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", fset.Position(f.Pos()), err)
+				}
+				if len(f.Names) == 0 {
+					return nil, fmt.Errorf("%s: embedded fields are not supported", fset.Position(f.Pos()))
+				}
+				for _, n := range f.Names {
+					info.fields = append(info.fields, field{name: n.Name, typ: ft})
+				}
+			}
+			if len(info.fields) == 0 {
+				return nil, fmt.Errorf("%s: %s has no fields", fset.Position(ts.Pos()), info.name)
+			}
+			if info.code == 0 {
+				info.code = 0xF0
+				if len(info.fields) <= 5 {
+					info.code = 0xA0 + byte(len(info.fields)-1)*0x10
+				}
+			}
+			if !validCode(info.code, len(info.fields)) {
+				return nil, fmt.Errorf("%s: code 0x%02X cannot hold %d fields", fset.Position(ts.Pos()), info.code, len(info.fields))
+			}
+			structs = append(structs, info)
+			names[info.name] = true
+		}
+	}
+	if len(structs) == 0 {
+		return nil, errors.New("no structs annotated with " + directive)
+	}
+	for _, s := range structs {
+		for _, f := range s.fields {
+			if err := f.typ.check(names); err != nil {
+				return nil, fmt.Errorf("%s.%s: %v", s.name, f.name, err)
+			}
+		}
+	}
+
+	g := &generator{}
+	for _, s := range structs {
+		g.writeStruct(s)
+	}
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by bonegen. DO NOT EDIT.\n\npackage %s\n\n", file.Name.Name)
+	if g.errors {
+		out.WriteString("import (\n\t\"errors\"\n\n\t\"github.com/mrmcc3/bone-go\"\n)\n")
+	} else {
+		out.WriteString("import \"github.com/mrmcc3/bone-go\"\n")
+	}
+	out.Write(g.buf.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func findDirective(doc *ast.CommentGroup) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+	for _, c := range doc.List {
+		if c.Text == directive {
+			return "", true
+		}
+		if args, ok := strings.CutPrefix(c.Text, directive+" "); ok {
+			return strings.TrimSpace(args), true
+		}
+	}
+	return "", false
+}
+
+func (s *structInfo) parseArgs(args string) error {
+	for _, arg := range strings.Fields(args) {
+		v, ok := strings.CutPrefix(arg, "code=")
+		if !ok {
+			return fmt.Errorf("unknown directive argument %q", arg)
+		}
+		code, err := strconv.ParseUint(v, 0, 8)
+		if err != nil {
+			return fmt.Errorf("invalid code %q", v)
+		}
+		s.code = byte(code)
+	}
+	return nil
+}
+
+func validCode(code byte, fields int) bool {
+	if code >= 0xF0 && code < 0xFF {
+		return true
+	}
+	return code >= 0xA0 && code < 0xF0 && int(code-0xA0)/0x10+1 == fields
+}
+
+func parseType(expr ast.Expr) (fieldType, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if kind, ok := scalars[t.Name]; ok {
+			return fieldType{kind: kind + ":" + t.Name}, nil
+		}
+		return fieldType{kind: "struct:" + t.Name}, nil
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return fieldType{}, errors.New("arrays are not supported")
+		}
+		if id, ok := t.Elt.(*ast.Ident); ok && (id.Name == "byte" || id.Name == "uint8") {
+			return fieldType{kind: "bytes:[]byte"}, nil
+		}
+		elem, err := parseType(t.Elt)
+		if err != nil {
+			return fieldType{}, err
+		}
+		return fieldType{kind: "slice:", elem: &elem}, nil
+	}
+	return fieldType{}, fmt.Errorf("unsupported field type %T", expr)
+}
+
+func (t fieldType) class() string {
+	c, _, _ := strings.Cut(t.kind, ":")
+	return c
+}
+
+func (t fieldType) goType() string {
+	if t.elem != nil {
+		return "[]" + t.elem.goType()
+	}
+	_, name, _ := strings.Cut(t.kind, ":")
+	return name
+}
+
+func (t fieldType) check(names map[string]bool) error {
+	switch t.class() {
+	case "struct":
+		if !names[t.goType()] {
+			return fmt.Errorf("type %s is not annotated with %s", t.goType(), directive)
+		}
+	case "slice":
+		return t.elem.check(names)
+	}
+	return nil
+}
+
+type generator struct {
+	buf    bytes.Buffer
+	errors bool
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) writeStruct(s *structInfo) {
+	list := s.code >= 0xF0
+	g.printf("\nfunc (x *%s) AppendBONE(dst []byte) []byte {\n", s.name)
+	g.printf("dst = append(dst, 0x%02X)\n", s.code)
+	for _, f := range s.fields {
+		g.appendValue(f.typ, "x."+f.name, 0)
+	}
+	if list {
+		g.printf("dst = append(dst, 0x00)\n")
+	}
+	g.printf("return dst\n}\n")
+
+	g.printf("\nfunc (x *%s) DecodeBONE(src []byte) (int, error) {\n", s.name)
+	g.printf("n, err := bone.ReadCode(src, 0x%02X)\nif err != nil {\nreturn 0, err\n}\n", s.code)
+	for _, f := range s.fields {
+		g.decodeValue(f.typ, "x."+f.name, 0)
+	}
+	if list {
+		g.errors = true
+		g.printf("if n >= len(src) || src[n] != 0x00 {\nreturn n, errors.New(\"expected list terminator\")\n}\nn++\n")
+	}
+	g.printf("return n, nil\n}\n")
+}
+
+func (g *generator) appendValue(t fieldType, expr string, depth int) {
+	switch t.class() {
+	case "bool":
+		g.printf("dst = bone.AppendBool(dst, %s)\n", expr)
+	case "int":
+		if t.goType() == "int64" {
+			g.printf("dst = bone.AppendInt(dst, %s)\n", expr)
+		} else {
+			g.printf("dst = bone.AppendInt(dst, int64(%s))\n", expr)
+		}
+	case "float":
+		g.printf("dst = bone.AppendFloat(dst, %s)\n", expr)
+	case "text":
+		g.printf("dst = bone.AppendText(dst, %s)\n", expr)
+	case "bytes":
+		g.printf("dst = bone.AppendBytes(dst, %s)\n", expr)
+	case "struct":
+		g.printf("dst = %s.AppendBONE(dst)\n", expr)
+	case "slice":
+		i := fmt.Sprintf("i%d", depth)
+		g.printf("dst = append(dst, 0xF0)\nfor %s := range %s {\n", i, expr)
+		g.appendValue(*t.elem, expr+"["+i+"]", depth+1)
+		g.printf("}\ndst = append(dst, 0x00)\n")
+	}
+}
+
+var readers = map[string]string{
+	"bool":  "bone.ReadBool",
+	"int":   "bone.ReadInt",
+	"float": "bone.ReadFloat",
+	"text":  "bone.ReadText",
+}
+
+func (g *generator) decodeValue(t fieldType, target string, depth int) {
+	switch t.class() {
+	case "struct":
+		g.printf("{\nm, err := %s.DecodeBONE(src[n:])\nif err != nil {\nreturn n, err\n}\nn += m\n}\n", target)
+	case "slice":
+		g.errors = true
+		e := fmt.Sprintf("e%d", depth)
+		g.printf("{\nm, err := bone.ReadCode(src[n:], 0xF0)\nif err != nil {\nreturn n, err\n}\nn += m\n")
+		g.printf("%s = %s[:0]\nfor n < len(src) && src[n] != 0x00 {\nvar %s %s\n", target, target, e, t.elem.goType())
+		g.decodeValue(*t.elem, e, depth+1)
+		g.printf("%s = append(%s, %s)\n}\n", target, target, e)
+		g.printf("if n >= len(src) {\nreturn n, errors.New(\"unterminated list\")\n}\nn++\n}\n")
+	case "bytes":
+		// Reuse the field's buffer rather than allocate a new one.
+		g.printf("{\nv, m, err := bone.ReadBytesInto(%s[:0], src[n:])\nif err != nil {\nreturn n, err\n}\n", target)
+		g.printf("%s = v\nn += m\n}\n", target)
+	default:
+		g.printf("{\nv, m, err := %s(src[n:])\nif err != nil {\nreturn n, err\n}\n", readers[t.class()])
+		if typ := t.goType(); t.class() == "int" && typ != "int64" && typ != "int" {
+			g.errors = true
+			g.printf("if int64(%s(v)) != v {\nreturn n, errors.New(\"int overflow\")\n}\n", typ)
+		}
+		if t.class() == "int" && t.goType() != "int64" {
+			g.printf("%s = %s(v)\n", target, t.goType())
+		} else {
+			g.printf("%s = v\n", target)
+		}
+		g.printf("n += m\n}\n")
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	src, err := os.ReadFile("example/example.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden, err := os.ReadFile("example/example_bone.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Generate("example.go", src)
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	if !bytes.Equal(got, golden) {
+		t.Errorf("Generated code differs from example/example_bone.go, run go generate ./...")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	cases := map[string]string{
+		"no annotations":  "package p\ntype T struct{ A int }\n",
+		"not a struct":    "package p\n//bone:generate\ntype T int\n",
+		"no fields":       "package p\n//bone:generate\ntype T struct{}\n",
+		"pointer field":   "package p\n//bone:generate\ntype T struct{ A *int }\n",
+		"map field":       "package p\n//bone:generate\ntype T struct{ A map[string]int }\n",
+		"array field":     "package p\n//bone:generate\ntype T struct{ A [4]int }\n",
+		"unannotated":     "package p\ntype U struct{ A int }\n//bone:generate\ntype T struct{ U U }\n",
+		"embedded":        "package p\n//bone:generate\ntype T struct{ U }\n//bone:generate\ntype U struct{ A int }\n",
+		"tuple arity":     "package p\n//bone:generate code=0xB0\ntype T struct{ A int }\n",
+		"invalid code":    "package p\n//bone:generate code=0x20\ntype T struct{ A int }\n",
+		"unknown options": "package p\n//bone:generate fast\ntype T struct{ A int }\n",
+	}
+	for name, src := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Generate("t.go", []byte(src)); err == nil {
+				t.Errorf("Expected error for %q", src)
+			}
+		})
+	}
+}
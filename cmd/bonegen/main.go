@@ -0,0 +1,61 @@
+// Command bonegen generates reflection-free BONE codecs for Go structs.
+//
+// Structs whose doc comment contains a //bone:generate line get two methods:
+//
+//	func (x *T) AppendBONE(dst []byte) []byte
+//	func (x *T) DecodeBONE(src []byte) (int, error)
+//
+// AppendBONE does not allocate beyond growing dst. DecodeBONE allocates only
+// the strings it decodes and any slice or []byte field that must grow, so
+// decoding repeatedly into the same value reuses its buffers.
+//
+// A struct with 1 to 5 fields is written as a tuple (codes 0xA0-0xE0), larger
+// structs as a list (code 0xF0). The directive may override the code, as in
+// //bone:generate code=0xB3. Supported field types are bool, signed integers,
+// uint8 to uint32, float64, string, []byte, other generated structs and slices
+// of any of these, which are written as lists. Fields tagged `bone:"-"` are
+// skipped.
+//
+// Run it through go generate:
+//
+//	//go:generate go run github.com/mrmcc3/bone-go/cmd/bonegen
+//
+// which reads $GOFILE and writes its output next to it with a _bone.go suffix.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	out := flag.String("o", "", "output file (default <input>_bone.go)")
+	flag.Parse()
+	in := flag.Arg(0)
+	if in == "" {
+		in = os.Getenv("GOFILE")
+	}
+	if in == "" {
+		fmt.Fprintln(os.Stderr, "usage: bonegen [-o output] file.go")
+		os.Exit(2)
+	}
+	if *out == "" {
+		*out = strings.TrimSuffix(in, ".go") + "_bone.go"
+	}
+	src, err := os.ReadFile(in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "bonegen:", err)
+		os.Exit(1)
+	}
+	code, err := Generate(in, src)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "bonegen:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "bonegen:", err)
+		os.Exit(1)
+	}
+}
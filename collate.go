@@ -0,0 +1,90 @@
+package bone
+
+import (
+	"bytes"
+	"errors"
+	"unicode"
+	"unicode/utf8"
+)
+
+var expansions = map[rune]string{
+	'ß': "ss", 'ẞ': "SS",
+	'æ': "ae", 'Æ': "AE",
+	'œ': "oe", 'Œ': "OE",
+	'ĳ': "ij", 'Ĳ': "IJ",
+	'ð': "d", 'Ð': "D",
+	'þ': "th", 'Þ': "TH",
+}
+
+var strokes = map[rune][2]rune{
+	'ø': {'o', 0x0338}, 'Ø': {'O', 0x0338},
+	'ł': {'l', 0x0338}, 'Ł': {'L', 0x0338},
+	'đ': {'d', 0x0335}, 'Đ': {'D', 0x0335},
+	'ħ': {'h', 0x0335}, 'Ħ': {'H', 0x0335},
+}
+
+// CollationKey computes a locale independent sort key for s. The key has three
+// levels separated by 0x01: base letters with case and accents removed, one
+// accent weight per letter and one case weight per letter. Comparing keys
+// bytewise sorts "apple" before "Zebra" and "resume" before "Resume" before
+// "résumé". Control characters are ignored. The key never contains 0x00.
+func CollationKey(s string) []byte {
+	var primary, secondary, tertiary []byte
+	add := func(base, mark rune) {
+		lower := unicode.ToLower(base)
+		primary = utf8.AppendRune(primary, lower)
+		if mark == 0 {
+			secondary = append(secondary, 0x02)
+		} else {
+			secondary = append(secondary, byte(0x03+mark-0x0300))
+		}
+		if lower != base {
+			tertiary = append(tertiary, 0x03)
+		} else {
+			tertiary = append(tertiary, 0x02)
+		}
+	}
+	for _, r := range s {
+		switch {
+		case unicode.IsControl(r):
+		case r >= 0x0300 && r < 0x0370:
+			if n := len(secondary); n > 0 && secondary[n-1] == 0x02 {
+				secondary[n-1] = byte(0x03 + r - 0x0300)
+			}
+		case expansions[r] != "":
+			for _, e := range expansions[r] {
+				add(e, 0)
+			}
+		case strokes[r][0] != 0:
+			add(strokes[r][0], strokes[r][1])
+		case decompositions[r][0] != 0:
+			add(decompositions[r][0], decompositions[r][1])
+		default:
+			add(r, 0)
+		}
+	}
+	key := append(primary, 0x01)
+	key = append(key, secondary...)
+	key = append(key, 0x01)
+	return append(key, tertiary...)
+}
+
+// NewCollated returns a collated string (code 0x92) holding the collation key
+// of s, a 0x00 separator and then s itself. Collated strings sort by key, with
+// the raw text breaking ties, and escape like any other 0x90 family string.
+func NewCollated(s string) *Value {
+	b := CollationKey(s)
+	b = append(b, 0x00)
+	return &Value{Code: CodeCollated, Bytes: append(b, s...)}
+}
+
+func (v *Value) Collated() (string, error) {
+	if v.Code != CodeCollated {
+		return "", errors.New("not a collated string")
+	}
+	i := bytes.IndexByte(v.Bytes, 0x00)
+	if i < 0 {
+		return "", errors.New("malformed collated string")
+	}
+	return string(v.Bytes[i+1:]), nil
+}
@@ -0,0 +1,494 @@
+package bone
+
+// decompositions maps precomposed Latin letters to their base letter and first
+// combining mark, following the Unicode canonical decompositions.
+var decompositions = map[rune][2]rune{
+	0x00C0: {'A', 0x0300}, // À
+	0x00C1: {'A', 0x0301}, // Á
+	0x00C2: {'A', 0x0302}, // Â
+	0x00C3: {'A', 0x0303}, // Ã
+	0x00C4: {'A', 0x0308}, // Ä
+	0x00C5: {'A', 0x030A}, // Å
+	0x00C7: {'C', 0x0327}, // Ç
+	0x00C8: {'E', 0x0300}, // È
+	0x00C9: {'E', 0x0301}, // É
+	0x00CA: {'E', 0x0302}, // Ê
+	0x00CB: {'E', 0x0308}, // Ë
+	0x00CC: {'I', 0x0300}, // Ì
+	0x00CD: {'I', 0x0301}, // Í
+	0x00CE: {'I', 0x0302}, // Î
+	0x00CF: {'I', 0x0308}, // Ï
+	0x00D1: {'N', 0x0303}, // Ñ
+	0x00D2: {'O', 0x0300}, // Ò
+	0x00D3: {'O', 0x0301}, // Ó
+	0x00D4: {'O', 0x0302}, // Ô
+	0x00D5: {'O', 0x0303}, // Õ
+	0x00D6: {'O', 0x0308}, // Ö
+	0x00D9: {'U', 0x0300}, // Ù
+	0x00DA: {'U', 0x0301}, // Ú
+	0x00DB: {'U', 0x0302}, // Û
+	0x00DC: {'U', 0x0308}, // Ü
+	0x00DD: {'Y', 0x0301}, // Ý
+	0x00E0: {'a', 0x0300}, // à
+	0x00E1: {'a', 0x0301}, // á
+	0x00E2: {'a', 0x0302}, // â
+	0x00E3: {'a', 0x0303}, // ã
+	0x00E4: {'a', 0x0308}, // ä
+	0x00E5: {'a', 0x030A}, // å
+	0x00E7: {'c', 0x0327}, // ç
+	0x00E8: {'e', 0x0300}, // è
+	0x00E9: {'e', 0x0301}, // é
+	0x00EA: {'e', 0x0302}, // ê
+	0x00EB: {'e', 0x0308}, // ë
+	0x00EC: {'i', 0x0300}, // ì
+	0x00ED: {'i', 0x0301}, // í
+	0x00EE: {'i', 0x0302}, // î
+	0x00EF: {'i', 0x0308}, // ï
+	0x00F1: {'n', 0x0303}, // ñ
+	0x00F2: {'o', 0x0300}, // ò
+	0x00F3: {'o', 0x0301}, // ó
+	0x00F4: {'o', 0x0302}, // ô
+	0x00F5: {'o', 0x0303}, // õ
+	0x00F6: {'o', 0x0308}, // ö
+	0x00F9: {'u', 0x0300}, // ù
+	0x00FA: {'u', 0x0301}, // ú
+	0x00FB: {'u', 0x0302}, // û
+	0x00FC: {'u', 0x0308}, // ü
+	0x00FD: {'y', 0x0301}, // ý
+	0x00FF: {'y', 0x0308}, // ÿ
+	0x0100: {'A', 0x0304}, // Ā
+	0x0101: {'a', 0x0304}, // ā
+	0x0102: {'A', 0x0306}, // Ă
+	0x0103: {'a', 0x0306}, // ă
+	0x0104: {'A', 0x0328}, // Ą
+	0x0105: {'a', 0x0328}, // ą
+	0x0106: {'C', 0x0301}, // Ć
+	0x0107: {'c', 0x0301}, // ć
+	0x0108: {'C', 0x0302}, // Ĉ
+	0x0109: {'c', 0x0302}, // ĉ
+	0x010A: {'C', 0x0307}, // Ċ
+	0x010B: {'c', 0x0307}, // ċ
+	0x010C: {'C', 0x030C}, // Č
+	0x010D: {'c', 0x030C}, // č
+	0x010E: {'D', 0x030C}, // Ď
+	0x010F: {'d', 0x030C}, // ď
+	0x0112: {'E', 0x0304}, // Ē
+	0x0113: {'e', 0x0304}, // ē
+	0x0114: {'E', 0x0306}, // Ĕ
+	0x0115: {'e', 0x0306}, // ĕ
+	0x0116: {'E', 0x0307}, // Ė
+	0x0117: {'e', 0x0307}, // ė
+	0x0118: {'E', 0x0328}, // Ę
+	0x0119: {'e', 0x0328}, // ę
+	0x011A: {'E', 0x030C}, // Ě
+	0x011B: {'e', 0x030C}, // ě
+	0x011C: {'G', 0x0302}, // Ĝ
+	0x011D: {'g', 0x0302}, // ĝ
+	0x011E: {'G', 0x0306}, // Ğ
+	0x011F: {'g', 0x0306}, // ğ
+	0x0120: {'G', 0x0307}, // Ġ
+	0x0121: {'g', 0x0307}, // ġ
+	0x0122: {'G', 0x0327}, // Ģ
+	0x0123: {'g', 0x0327}, // ģ
+	0x0124: {'H', 0x0302}, // Ĥ
+	0x0125: {'h', 0x0302}, // ĥ
+	0x0128: {'I', 0x0303}, // Ĩ
+	0x0129: {'i', 0x0303}, // ĩ
+	0x012A: {'I', 0x0304}, // Ī
+	0x012B: {'i', 0x0304}, // ī
+	0x012C: {'I', 0x0306}, // Ĭ
+	0x012D: {'i', 0x0306}, // ĭ
+	0x012E: {'I', 0x0328}, // Į
+	0x012F: {'i', 0x0328}, // į
+	0x0130: {'I', 0x0307}, // İ
+	0x0134: {'J', 0x0302}, // Ĵ
+	0x0135: {'j', 0x0302}, // ĵ
+	0x0136: {'K', 0x0327}, // Ķ
+	0x0137: {'k', 0x0327}, // ķ
+	0x0139: {'L', 0x0301}, // Ĺ
+	0x013A: {'l', 0x0301}, // ĺ
+	0x013B: {'L', 0x0327}, // Ļ
+	0x013C: {'l', 0x0327}, // ļ
+	0x013D: {'L', 0x030C}, // Ľ
+	0x013E: {'l', 0x030C}, // ľ
+	0x0143: {'N', 0x0301}, // Ń
+	0x0144: {'n', 0x0301}, // ń
+	0x0145: {'N', 0x0327}, // Ņ
+	0x0146: {'n', 0x0327}, // ņ
+	0x0147: {'N', 0x030C}, // Ň
+	0x0148: {'n', 0x030C}, // ň
+	0x014C: {'O', 0x0304}, // Ō
+	0x014D: {'o', 0x0304}, // ō
+	0x014E: {'O', 0x0306}, // Ŏ
+	0x014F: {'o', 0x0306}, // ŏ
+	0x0150: {'O', 0x030B}, // Ő
+	0x0151: {'o', 0x030B}, // ő
+	0x0154: {'R', 0x0301}, // Ŕ
+	0x0155: {'r', 0x0301}, // ŕ
+	0x0156: {'R', 0x0327}, // Ŗ
+	0x0157: {'r', 0x0327}, // ŗ
+	0x0158: {'R', 0x030C}, // Ř
+	0x0159: {'r', 0x030C}, // ř
+	0x015A: {'S', 0x0301}, // Ś
+	0x015B: {'s', 0x0301}, // ś
+	0x015C: {'S', 0x0302}, // Ŝ
+	0x015D: {'s', 0x0302}, // ŝ
+	0x015E: {'S', 0x0327}, // Ş
+	0x015F: {'s', 0x0327}, // ş
+	0x0160: {'S', 0x030C}, // Š
+	0x0161: {'s', 0x030C}, // š
+	0x0162: {'T', 0x0327}, // Ţ
+	0x0163: {'t', 0x0327}, // ţ
+	0x0164: {'T', 0x030C}, // Ť
+	0x0165: {'t', 0x030C}, // ť
+	0x0168: {'U', 0x0303}, // Ũ
+	0x0169: {'u', 0x0303}, // ũ
+	0x016A: {'U', 0x0304}, // Ū
+	0x016B: {'u', 0x0304}, // ū
+	0x016C: {'U', 0x0306}, // Ŭ
+	0x016D: {'u', 0x0306}, // ŭ
+	0x016E: {'U', 0x030A}, // Ů
+	0x016F: {'u', 0x030A}, // ů
+	0x0170: {'U', 0x030B}, // Ű
+	0x0171: {'u', 0x030B}, // ű
+	0x0172: {'U', 0x0328}, // Ų
+	0x0173: {'u', 0x0328}, // ų
+	0x0174: {'W', 0x0302}, // Ŵ
+	0x0175: {'w', 0x0302}, // ŵ
+	0x0176: {'Y', 0x0302}, // Ŷ
+	0x0177: {'y', 0x0302}, // ŷ
+	0x0178: {'Y', 0x0308}, // Ÿ
+	0x0179: {'Z', 0x0301}, // Ź
+	0x017A: {'z', 0x0301}, // ź
+	0x017B: {'Z', 0x0307}, // Ż
+	0x017C: {'z', 0x0307}, // ż
+	0x017D: {'Z', 0x030C}, // Ž
+	0x017E: {'z', 0x030C}, // ž
+	0x01A0: {'O', 0x031B}, // Ơ
+	0x01A1: {'o', 0x031B}, // ơ
+	0x01AF: {'U', 0x031B}, // Ư
+	0x01B0: {'u', 0x031B}, // ư
+	0x01CD: {'A', 0x030C}, // Ǎ
+	0x01CE: {'a', 0x030C}, // ǎ
+	0x01CF: {'I', 0x030C}, // Ǐ
+	0x01D0: {'i', 0x030C}, // ǐ
+	0x01D1: {'O', 0x030C}, // Ǒ
+	0x01D2: {'o', 0x030C}, // ǒ
+	0x01D3: {'U', 0x030C}, // Ǔ
+	0x01D4: {'u', 0x030C}, // ǔ
+	0x01D5: {'U', 0x0308}, // Ǖ
+	0x01D6: {'u', 0x0308}, // ǖ
+	0x01D7: {'U', 0x0308}, // Ǘ
+	0x01D8: {'u', 0x0308}, // ǘ
+	0x01D9: {'U', 0x0308}, // Ǚ
+	0x01DA: {'u', 0x0308}, // ǚ
+	0x01DB: {'U', 0x0308}, // Ǜ
+	0x01DC: {'u', 0x0308}, // ǜ
+	0x01DE: {'A', 0x0308}, // Ǟ
+	0x01DF: {'a', 0x0308}, // ǟ
+	0x01E0: {'A', 0x0307}, // Ǡ
+	0x01E1: {'a', 0x0307}, // ǡ
+	0x01E6: {'G', 0x030C}, // Ǧ
+	0x01E7: {'g', 0x030C}, // ǧ
+	0x01E8: {'K', 0x030C}, // Ǩ
+	0x01E9: {'k', 0x030C}, // ǩ
+	0x01EA: {'O', 0x0328}, // Ǫ
+	0x01EB: {'o', 0x0328}, // ǫ
+	0x01EC: {'O', 0x0328}, // Ǭ
+	0x01ED: {'o', 0x0328}, // ǭ
+	0x01F0: {'j', 0x030C}, // ǰ
+	0x01F4: {'G', 0x0301}, // Ǵ
+	0x01F5: {'g', 0x0301}, // ǵ
+	0x01F8: {'N', 0x0300}, // Ǹ
+	0x01F9: {'n', 0x0300}, // ǹ
+	0x01FA: {'A', 0x030A}, // Ǻ
+	0x01FB: {'a', 0x030A}, // ǻ
+	0x0200: {'A', 0x030F}, // Ȁ
+	0x0201: {'a', 0x030F}, // ȁ
+	0x0202: {'A', 0x0311}, // Ȃ
+	0x0203: {'a', 0x0311}, // ȃ
+	0x0204: {'E', 0x030F}, // Ȅ
+	0x0205: {'e', 0x030F}, // ȅ
+	0x0206: {'E', 0x0311}, // Ȇ
+	0x0207: {'e', 0x0311}, // ȇ
+	0x0208: {'I', 0x030F}, // Ȉ
+	0x0209: {'i', 0x030F}, // ȉ
+	0x020A: {'I', 0x0311}, // Ȋ
+	0x020B: {'i', 0x0311}, // ȋ
+	0x020C: {'O', 0x030F}, // Ȍ
+	0x020D: {'o', 0x030F}, // ȍ
+	0x020E: {'O', 0x0311}, // Ȏ
+	0x020F: {'o', 0x0311}, // ȏ
+	0x0210: {'R', 0x030F}, // Ȑ
+	0x0211: {'r', 0x030F}, // ȑ
+	0x0212: {'R', 0x0311}, // Ȓ
+	0x0213: {'r', 0x0311}, // ȓ
+	0x0214: {'U', 0x030F}, // Ȕ
+	0x0215: {'u', 0x030F}, // ȕ
+	0x0216: {'U', 0x0311}, // Ȗ
+	0x0217: {'u', 0x0311}, // ȗ
+	0x0218: {'S', 0x0326}, // Ș
+	0x0219: {'s', 0x0326}, // ș
+	0x021A: {'T', 0x0326}, // Ț
+	0x021B: {'t', 0x0326}, // ț
+	0x021E: {'H', 0x030C}, // Ȟ
+	0x021F: {'h', 0x030C}, // ȟ
+	0x0226: {'A', 0x0307}, // Ȧ
+	0x0227: {'a', 0x0307}, // ȧ
+	0x0228: {'E', 0x0327}, // Ȩ
+	0x0229: {'e', 0x0327}, // ȩ
+	0x022A: {'O', 0x0308}, // Ȫ
+	0x022B: {'o', 0x0308}, // ȫ
+	0x022C: {'O', 0x0303}, // Ȭ
+	0x022D: {'o', 0x0303}, // ȭ
+	0x022E: {'O', 0x0307}, // Ȯ
+	0x022F: {'o', 0x0307}, // ȯ
+	0x0230: {'O', 0x0307}, // Ȱ
+	0x0231: {'o', 0x0307}, // ȱ
+	0x0232: {'Y', 0x0304}, // Ȳ
+	0x0233: {'y', 0x0304}, // ȳ
+	0x1E00: {'A', 0x0325}, // Ḁ
+	0x1E01: {'a', 0x0325}, // ḁ
+	0x1E02: {'B', 0x0307}, // Ḃ
+	0x1E03: {'b', 0x0307}, // ḃ
+	0x1E04: {'B', 0x0323}, // Ḅ
+	0x1E05: {'b', 0x0323}, // ḅ
+	0x1E06: {'B', 0x0331}, // Ḇ
+	0x1E07: {'b', 0x0331}, // ḇ
+	0x1E08: {'C', 0x0327}, // Ḉ
+	0x1E09: {'c', 0x0327}, // ḉ
+	0x1E0A: {'D', 0x0307}, // Ḋ
+	0x1E0B: {'d', 0x0307}, // ḋ
+	0x1E0C: {'D', 0x0323}, // Ḍ
+	0x1E0D: {'d', 0x0323}, // ḍ
+	0x1E0E: {'D', 0x0331}, // Ḏ
+	0x1E0F: {'d', 0x0331}, // ḏ
+	0x1E10: {'D', 0x0327}, // Ḑ
+	0x1E11: {'d', 0x0327}, // ḑ
+	0x1E12: {'D', 0x032D}, // Ḓ
+	0x1E13: {'d', 0x032D}, // ḓ
+	0x1E14: {'E', 0x0304}, // Ḕ
+	0x1E15: {'e', 0x0304}, // ḕ
+	0x1E16: {'E', 0x0304}, // Ḗ
+	0x1E17: {'e', 0x0304}, // ḗ
+	0x1E18: {'E', 0x032D}, // Ḙ
+	0x1E19: {'e', 0x032D}, // ḙ
+	0x1E1A: {'E', 0x0330}, // Ḛ
+	0x1E1B: {'e', 0x0330}, // ḛ
+	0x1E1C: {'E', 0x0327}, // Ḝ
+	0x1E1D: {'e', 0x0327}, // ḝ
+	0x1E1E: {'F', 0x0307}, // Ḟ
+	0x1E1F: {'f', 0x0307}, // ḟ
+	0x1E20: {'G', 0x0304}, // Ḡ
+	0x1E21: {'g', 0x0304}, // ḡ
+	0x1E22: {'H', 0x0307}, // Ḣ
+	0x1E23: {'h', 0x0307}, // ḣ
+	0x1E24: {'H', 0x0323}, // Ḥ
+	0x1E25: {'h', 0x0323}, // ḥ
+	0x1E26: {'H', 0x0308}, // Ḧ
+	0x1E27: {'h', 0x0308}, // ḧ
+	0x1E28: {'H', 0x0327}, // Ḩ
+	0x1E29: {'h', 0x0327}, // ḩ
+	0x1E2A: {'H', 0x032E}, // Ḫ
+	0x1E2B: {'h', 0x032E}, // ḫ
+	0x1E2C: {'I', 0x0330}, // Ḭ
+	0x1E2D: {'i', 0x0330}, // ḭ
+	0x1E2E: {'I', 0x0308}, // Ḯ
+	0x1E2F: {'i', 0x0308}, // ḯ
+	0x1E30: {'K', 0x0301}, // Ḱ
+	0x1E31: {'k', 0x0301}, // ḱ
+	0x1E32: {'K', 0x0323}, // Ḳ
+	0x1E33: {'k', 0x0323}, // ḳ
+	0x1E34: {'K', 0x0331}, // Ḵ
+	0x1E35: {'k', 0x0331}, // ḵ
+	0x1E36: {'L', 0x0323}, // Ḷ
+	0x1E37: {'l', 0x0323}, // ḷ
+	0x1E38: {'L', 0x0323}, // Ḹ
+	0x1E39: {'l', 0x0323}, // ḹ
+	0x1E3A: {'L', 0x0331}, // Ḻ
+	0x1E3B: {'l', 0x0331}, // ḻ
+	0x1E3C: {'L', 0x032D}, // Ḽ
+	0x1E3D: {'l', 0x032D}, // ḽ
+	0x1E3E: {'M', 0x0301}, // Ḿ
+	0x1E3F: {'m', 0x0301}, // ḿ
+	0x1E40: {'M', 0x0307}, // Ṁ
+	0x1E41: {'m', 0x0307}, // ṁ
+	0x1E42: {'M', 0x0323}, // Ṃ
+	0x1E43: {'m', 0x0323}, // ṃ
+	0x1E44: {'N', 0x0307}, // Ṅ
+	0x1E45: {'n', 0x0307}, // ṅ
+	0x1E46: {'N', 0x0323}, // Ṇ
+	0x1E47: {'n', 0x0323}, // ṇ
+	0x1E48: {'N', 0x0331}, // Ṉ
+	0x1E49: {'n', 0x0331}, // ṉ
+	0x1E4A: {'N', 0x032D}, // Ṋ
+	0x1E4B: {'n', 0x032D}, // ṋ
+	0x1E4C: {'O', 0x0303}, // Ṍ
+	0x1E4D: {'o', 0x0303}, // ṍ
+	0x1E4E: {'O', 0x0303}, // Ṏ
+	0x1E4F: {'o', 0x0303}, // ṏ
+	0x1E50: {'O', 0x0304}, // Ṑ
+	0x1E51: {'o', 0x0304}, // ṑ
+	0x1E52: {'O', 0x0304}, // Ṓ
+	0x1E53: {'o', 0x0304}, // ṓ
+	0x1E54: {'P', 0x0301}, // Ṕ
+	0x1E55: {'p', 0x0301}, // ṕ
+	0x1E56: {'P', 0x0307}, // Ṗ
+	0x1E57: {'p', 0x0307}, // ṗ
+	0x1E58: {'R', 0x0307}, // Ṙ
+	0x1E59: {'r', 0x0307}, // ṙ
+	0x1E5A: {'R', 0x0323}, // Ṛ
+	0x1E5B: {'r', 0x0323}, // ṛ
+	0x1E5C: {'R', 0x0323}, // Ṝ
+	0x1E5D: {'r', 0x0323}, // ṝ
+	0x1E5E: {'R', 0x0331}, // Ṟ
+	0x1E5F: {'r', 0x0331}, // ṟ
+	0x1E60: {'S', 0x0307}, // Ṡ
+	0x1E61: {'s', 0x0307}, // ṡ
+	0x1E62: {'S', 0x0323}, // Ṣ
+	0x1E63: {'s', 0x0323}, // ṣ
+	0x1E64: {'S', 0x0301}, // Ṥ
+	0x1E65: {'s', 0x0301}, // ṥ
+	0x1E66: {'S', 0x030C}, // Ṧ
+	0x1E67: {'s', 0x030C}, // ṧ
+	0x1E68: {'S', 0x0323}, // Ṩ
+	0x1E69: {'s', 0x0323}, // ṩ
+	0x1E6A: {'T', 0x0307}, // Ṫ
+	0x1E6B: {'t', 0x0307}, // ṫ
+	0x1E6C: {'T', 0x0323}, // Ṭ
+	0x1E6D: {'t', 0x0323}, // ṭ
+	0x1E6E: {'T', 0x0331}, // Ṯ
+	0x1E6F: {'t', 0x0331}, // ṯ
+	0x1E70: {'T', 0x032D}, // Ṱ
+	0x1E71: {'t', 0x032D}, // ṱ
+	0x1E72: {'U', 0x0324}, // Ṳ
+	0x1E73: {'u', 0x0324}, // ṳ
+	0x1E74: {'U', 0x0330}, // Ṵ
+	0x1E75: {'u', 0x0330}, // ṵ
+	0x1E76: {'U', 0x032D}, // Ṷ
+	0x1E77: {'u', 0x032D}, // ṷ
+	0x1E78: {'U', 0x0303}, // Ṹ
+	0x1E79: {'u', 0x0303}, // ṹ
+	0x1E7A: {'U', 0x0304}, // Ṻ
+	0x1E7B: {'u', 0x0304}, // ṻ
+	0x1E7C: {'V', 0x0303}, // Ṽ
+	0x1E7D: {'v', 0x0303}, // ṽ
+	0x1E7E: {'V', 0x0323}, // Ṿ
+	0x1E7F: {'v', 0x0323}, // ṿ
+	0x1E80: {'W', 0x0300}, // Ẁ
+	0x1E81: {'w', 0x0300}, // ẁ
+	0x1E82: {'W', 0x0301}, // Ẃ
+	0x1E83: {'w', 0x0301}, // ẃ
+	0x1E84: {'W', 0x0308}, // Ẅ
+	0x1E85: {'w', 0x0308}, // ẅ
+	0x1E86: {'W', 0x0307}, // Ẇ
+	0x1E87: {'w', 0x0307}, // ẇ
+	0x1E88: {'W', 0x0323}, // Ẉ
+	0x1E89: {'w', 0x0323}, // ẉ
+	0x1E8A: {'X', 0x0307}, // Ẋ
+	0x1E8B: {'x', 0x0307}, // ẋ
+	0x1E8C: {'X', 0x0308}, // Ẍ
+	0x1E8D: {'x', 0x0308}, // ẍ
+	0x1E8E: {'Y', 0x0307}, // Ẏ
+	0x1E8F: {'y', 0x0307}, // ẏ
+	0x1E90: {'Z', 0x0302}, // Ẑ
+	0x1E91: {'z', 0x0302}, // ẑ
+	0x1E92: {'Z', 0x0323}, // Ẓ
+	0x1E93: {'z', 0x0323}, // ẓ
+	0x1E94: {'Z', 0x0331}, // Ẕ
+	0x1E95: {'z', 0x0331}, // ẕ
+	0x1E96: {'h', 0x0331}, // ẖ
+	0x1E97: {'t', 0x0308}, // ẗ
+	0x1E98: {'w', 0x030A}, // ẘ
+	0x1E99: {'y', 0x030A}, // ẙ
+	0x1EA0: {'A', 0x0323}, // Ạ
+	0x1EA1: {'a', 0x0323}, // ạ
+	0x1EA2: {'A', 0x0309}, // Ả
+	0x1EA3: {'a', 0x0309}, // ả
+	0x1EA4: {'A', 0x0302}, // Ấ
+	0x1EA5: {'a', 0x0302}, // ấ
+	0x1EA6: {'A', 0x0302}, // Ầ
+	0x1EA7: {'a', 0x0302}, // ầ
+	0x1EA8: {'A', 0x0302}, // Ẩ
+	0x1EA9: {'a', 0x0302}, // ẩ
+	0x1EAA: {'A', 0x0302}, // Ẫ
+	0x1EAB: {'a', 0x0302}, // ẫ
+	0x1EAC: {'A', 0x0323}, // Ậ
+	0x1EAD: {'a', 0x0323}, // ậ
+	0x1EAE: {'A', 0x0306}, // Ắ
+	0x1EAF: {'a', 0x0306}, // ắ
+	0x1EB0: {'A', 0x0306}, // Ằ
+	0x1EB1: {'a', 0x0306}, // ằ
+	0x1EB2: {'A', 0x0306}, // Ẳ
+	0x1EB3: {'a', 0x0306}, // ẳ
+	0x1EB4: {'A', 0x0306}, // Ẵ
+	0x1EB5: {'a', 0x0306}, // ẵ
+	0x1EB6: {'A', 0x0323}, // Ặ
+	0x1EB7: {'a', 0x0323}, // ặ
+	0x1EB8: {'E', 0x0323}, // Ẹ
+	0x1EB9: {'e', 0x0323}, // ẹ
+	0x1EBA: {'E', 0x0309}, // Ẻ
+	0x1EBB: {'e', 0x0309}, // ẻ
+	0x1EBC: {'E', 0x0303}, // Ẽ
+	0x1EBD: {'e', 0x0303}, // ẽ
+	0x1EBE: {'E', 0x0302}, // Ế
+	0x1EBF: {'e', 0x0302}, // ế
+	0x1EC0: {'E', 0x0302}, // Ề
+	0x1EC1: {'e', 0x0302}, // ề
+	0x1EC2: {'E', 0x0302}, // Ể
+	0x1EC3: {'e', 0x0302}, // ể
+	0x1EC4: {'E', 0x0302}, // Ễ
+	0x1EC5: {'e', 0x0302}, // ễ
+	0x1EC6: {'E', 0x0323}, // Ệ
+	0x1EC7: {'e', 0x0323}, // ệ
+	0x1EC8: {'I', 0x0309}, // Ỉ
+	0x1EC9: {'i', 0x0309}, // ỉ
+	0x1ECA: {'I', 0x0323}, // Ị
+	0x1ECB: {'i', 0x0323}, // ị
+	0x1ECC: {'O', 0x0323}, // Ọ
+	0x1ECD: {'o', 0x0323}, // ọ
+	0x1ECE: {'O', 0x0309}, // Ỏ
+	0x1ECF: {'o', 0x0309}, // ỏ
+	0x1ED0: {'O', 0x0302}, // Ố
+	0x1ED1: {'o', 0x0302}, // ố
+	0x1ED2: {'O', 0x0302}, // Ồ
+	0x1ED3: {'o', 0x0302}, // ồ
+	0x1ED4: {'O', 0x0302}, // Ổ
+	0x1ED5: {'o', 0x0302}, // ổ
+	0x1ED6: {'O', 0x0302}, // Ỗ
+	0x1ED7: {'o', 0x0302}, // ỗ
+	0x1ED8: {'O', 0x0323}, // Ộ
+	0x1ED9: {'o', 0x0323}, // ộ
+	0x1EDA: {'O', 0x031B}, // Ớ
+	0x1EDB: {'o', 0x031B}, // ớ
+	0x1EDC: {'O', 0x031B}, // Ờ
+	0x1EDD: {'o', 0x031B}, // ờ
+	0x1EDE: {'O', 0x031B}, // Ở
+	0x1EDF: {'o', 0x031B}, // ở
+	0x1EE0: {'O', 0x031B}, // Ỡ
+	0x1EE1: {'o', 0x031B}, // ỡ
+	0x1EE2: {'O', 0x031B}, // Ợ
+	0x1EE3: {'o', 0x031B}, // ợ
+	0x1EE4: {'U', 0x0323}, // Ụ
+	0x1EE5: {'u', 0x0323}, // ụ
+	0x1EE6: {'U', 0x0309}, // Ủ
+	0x1EE7: {'u', 0x0309}, // ủ
+	0x1EE8: {'U', 0x031B}, // Ứ
+	0x1EE9: {'u', 0x031B}, // ứ
+	0x1EEA: {'U', 0x031B}, // Ừ
+	0x1EEB: {'u', 0x031B}, // ừ
+	0x1EEC: {'U', 0x031B}, // Ử
+	0x1EED: {'u', 0x031B}, // ử
+	0x1EEE: {'U', 0x031B}, // Ữ
+	0x1EEF: {'u', 0x031B}, // ữ
+	0x1EF0: {'U', 0x031B}, // Ự
+	0x1EF1: {'u', 0x031B}, // ự
+	0x1EF2: {'Y', 0x0300}, // Ỳ
+	0x1EF3: {'y', 0x0300}, // ỳ
+	0x1EF4: {'Y', 0x0323}, // Ỵ
+	0x1EF5: {'y', 0x0323}, // ỵ
+	0x1EF6: {'Y', 0x0309}, // Ỷ
+	0x1EF7: {'y', 0x0309}, // ỷ
+	0x1EF8: {'Y', 0x0303}, // Ỹ
+	0x1EF9: {'y', 0x0303}, // ỹ
+}
@@ -0,0 +1,67 @@
+package bone
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestCollated(t *testing.T) {
+	expected := []string{
+		"",
+		" zoo",
+		"1999",
+		"Äpfel",
+		"apple",
+		"Apple",
+		"banana",
+		"cote",
+		"Cote",
+		"coté",
+		"côte",
+		"côté",
+		"Éclair",
+		"eclairs",
+		"Ørsted",
+		"Straße",
+		"strasse2",
+		"Zebra",
+		"zebras",
+	}
+	shuffled := slices.Clone(expected)
+	slices.Reverse(shuffled)
+	values := make([]*Value, len(shuffled))
+	for i, s := range shuffled {
+		values[i] = NewCollated(s)
+	}
+	slices.SortFunc(values, Compare)
+	for i, v := range values {
+		s, err := v.Collated()
+		if err != nil {
+			t.Fatalf("Failed to read collated string: %v", err)
+		}
+		if s != expected[i] {
+			t.Errorf("Position %d: expected %q, got %q", i, expected[i], s)
+		}
+	}
+
+	decoded, err := Decode(Encode([]*Value{NewCollated("a\x00b")}))
+	if err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	if s, err := decoded[0].Collated(); err != nil || s != "a\x00b" {
+		t.Errorf("Expected %q, got %q, %v", "a\x00b", s, err)
+	}
+}
+
+func TestCollationKey(t *testing.T) {
+	if !bytes.Equal(CollationKey("é"), CollationKey("é")) {
+		t.Errorf("Expected decomposed and precomposed forms to share a key")
+	}
+	if bytes.IndexByte(CollationKey("a\x00b\x01c"), 0x00) >= 0 {
+		t.Errorf("Expected key without 0x00 bytes")
+	}
+	if _, err := NewText("x").Collated(); err == nil {
+		t.Errorf("Expected error reading text as collated string")
+	}
+}
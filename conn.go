@@ -0,0 +1,137 @@
+package bone
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"time"
+)
+
+// DefaultMaxMessageSize is the MaxMessageSize of a new Conn.
+const DefaultMaxMessageSize = 16 << 20
+
+// Conn sends and receives BONE values over a network connection. Each message
+// is a 4 byte big-endian length followed by the encoding of one value. Send
+// and Recv may run concurrently with each other, but neither may be called
+// from more than one goroutine at a time.
+type Conn struct {
+	// MaxMessageSize bounds the encoded size of values sent and received.
+	MaxMessageSize int
+	// ReadTimeout and WriteTimeout, when positive, bound each Recv and Send.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+
+	conn     net.Conn
+	r        *bufio.Reader
+	bw       *bufio.Writer
+	w        *Writer
+	d        Decoder
+	readErr  error
+	writeErr error
+}
+
+func NewConn(conn net.Conn) *Conn {
+	bw := bufio.NewWriter(conn)
+	return &Conn{
+		MaxMessageSize: DefaultMaxMessageSize,
+		conn:           conn,
+		r:              bufio.NewReader(conn),
+		bw:             bw,
+		// NewWriter reuses bw as its buffer, so the header and value share
+		// one flush.
+		w: NewWriter(bw),
+	}
+}
+
+// Send writes v as one message. Invalid or oversized values are rejected
+// before anything is written; any error after that is final, since the peer
+// may have received part of the message.
+func (c *Conn) Send(v *Value) error {
+	if c.writeErr != nil {
+		return c.writeErr
+	}
+	if err := validate(v); err != nil {
+		return err
+	}
+	n := EncodedLen(v)
+	if n > c.MaxMessageSize {
+		return errors.New("message too large")
+	}
+	if err := c.send(n, v); err != nil {
+		c.writeErr = err
+		return err
+	}
+	return nil
+}
+
+func (c *Conn) send(n int, v *Value) error {
+	if c.WriteTimeout > 0 {
+		if err := c.conn.SetWriteDeadline(time.Now().Add(c.WriteTimeout)); err != nil {
+			return err
+		}
+	}
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(n))
+	if _, err := c.bw.Write(header[:]); err != nil {
+		return err
+	}
+	if err := c.w.Value(v); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+// Recv returns the next value. A message that fails to decode is skipped and
+// reported without affecting later calls; errors reading from the connection
+// or an oversized message are final.
+func (c *Conn) Recv() (*Value, error) {
+	if c.readErr != nil {
+		return nil, c.readErr
+	}
+	if c.ReadTimeout > 0 {
+		if err := c.conn.SetReadDeadline(time.Now().Add(c.ReadTimeout)); err != nil {
+			return nil, err
+		}
+	}
+	var header [4]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		c.readErr = err
+		return nil, err
+	}
+	size := int(binary.BigEndian.Uint32(header[:]))
+	if size > c.MaxMessageSize {
+		c.readErr = errors.New("message too large")
+		return nil, c.readErr
+	}
+	c.d.Reset()
+	var decodeErr error
+	for size > 0 {
+		b, err := c.r.Peek(min(size, c.r.Size()))
+		if err != nil {
+			c.readErr = err
+			return nil, err
+		}
+		if decodeErr == nil {
+			_, decodeErr = c.d.Write(b)
+		}
+		c.r.Discard(len(b))
+		size -= len(b)
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	values, err := c.d.Finish()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 1 {
+		return nil, errors.New("message does not hold exactly one value")
+	}
+	return values[0], nil
+}
+
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,112 @@
+package bone
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConn(t *testing.T) {
+	a, b := net.Pipe()
+	client, server := NewConn(a), NewConn(b)
+	defer client.Close()
+	values := []*Value{
+		NewText("hello"),
+		List(Int(1), Raw([]byte(strings.Repeat("\x00x", 5000))), Tuple(Null)),
+		NewBytes(nil),
+	}
+	go func() {
+		for _, v := range values {
+			if err := client.Send(v); err != nil {
+				t.Errorf("Send failed: %v", err)
+				return
+			}
+		}
+		client.Close()
+	}()
+	for i, want := range values {
+		v, err := server.Recv()
+		if err != nil {
+			t.Fatalf("Message %d: unexpected error: %v", i, err)
+		}
+		if Compare(v, want) != 0 {
+			t.Errorf("Message %d: value mismatch", i)
+		}
+	}
+	if _, err := server.Recv(); err != io.EOF {
+		t.Errorf("Expected io.EOF after close, got %v", err)
+	}
+}
+
+func TestConnLimits(t *testing.T) {
+	a, b := net.Pipe()
+	client, server := NewConn(a), NewConn(b)
+	defer client.Close()
+	defer server.Close()
+
+	client.MaxMessageSize = 4
+	if err := client.Send(NewText("too long")); err == nil {
+		t.Error("Expected error sending oversized message")
+	}
+
+	client.MaxMessageSize = DefaultMaxMessageSize
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := client.Send(&Value{Code: 0x01}); err == nil {
+			t.Error("Expected error sending invalid value")
+		}
+		client.Send(NewInt(5))
+	}()
+	if v, err := server.Recv(); err != nil || Compare(v, NewInt(5)) != 0 {
+		t.Errorf("Expected 5 after rejected value, got %v", err)
+	}
+	<-done
+
+	client.WriteTimeout = 10 * time.Millisecond
+	if err := client.Send(NewInt(6)); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Expected write deadline error, got %v", err)
+	}
+	client.WriteTimeout = 0
+	if err := client.Send(NewInt(7)); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Expected write error to be final, got %v", err)
+	}
+
+	server.ReadTimeout = 10 * time.Millisecond
+	if _, err := server.Recv(); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Expected deadline error, got %v", err)
+	}
+}
+
+func TestConnInvalidMessages(t *testing.T) {
+	a, b := net.Pipe()
+	server := NewConn(b)
+	defer a.Close()
+	defer server.Close()
+	go func() {
+		frame := func(payload ...byte) []byte {
+			return append(binary.BigEndian.AppendUint32(nil, uint32(len(payload))), payload...)
+		}
+		a.Write(frame(0x10, 0x03, 0x10))
+		a.Write(frame(0x10, 0x11))
+		a.Write(frame(0x12))
+		a.Write([]byte{0x7F, 0xFF, 0xFF, 0xFF})
+	}()
+	for i := range 2 {
+		if _, err := server.Recv(); err == nil {
+			t.Errorf("Message %d: expected decode error", i)
+		}
+	}
+	if v, err := server.Recv(); err != nil || Compare(v, NewInt(2)) != 0 {
+		t.Errorf("Expected connection to recover after invalid messages, got %v", err)
+	}
+	server.MaxMessageSize = 1 << 20
+	if _, err := server.Recv(); err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Errorf("Expected message too large, got %v", err)
+	}
+}
@@ -1,121 +1,363 @@
 package bone
 
 import (
+	"bytes"
 	"errors"
+	"slices"
+	"unicode/utf8"
 )
 
+const (
+	kindIllegal = iota
+	kindBlock
+	kindString
+	kindTuple
+	kindList
+	kindLevel
+)
+
+type codeInfo struct {
+	kind  uint8
+	width uint8
+	arity uint8
+}
+
+var codeTable [256]codeInfo
+
+func init() {
+	for c := range 256 {
+		info := &codeTable[c]
+		switch {
+		case c < 0x08:
+			info.kind = kindIllegal
+		case c < 0x10:
+			info.kind, info.width = kindBlock, uint8(0x10-c)
+		case c < 0x18:
+			info.kind = kindBlock
+		case c < 0x20:
+			info.kind, info.width = kindBlock, uint8(c-0x17)
+		case c < 0x90:
+			info.kind, info.width = kindBlock, []uint8{0, 1, 2, 3, 4, 8, 16}[(c-0x20)/0x10]
+		case c < 0xA0:
+			info.kind = kindString
+		case c < 0xF0:
+			info.kind, info.arity = kindTuple, uint8((c-0xA0)/0x10+1)
+		case c < 0xFF:
+			info.kind = kindList
+		default:
+			info.kind = kindLevel
+		}
+	}
+}
+
+// Decoder incrementally decodes a stream of BONE values. Bytes may be fed one
+// at a time with Accept or in chunks of any size with Write; completed top
+// level values are appended to Values. Stack holds the values still being
+// decoded, innermost last, and Level the count of pending level extensions.
+//
+// When Arena is set, values are allocated from it. While a string or list is
+// being decoded its bytes or children are then buffered inside the decoder and
+// only copied into the value once it completes.
 type Decoder struct {
 	Values []*Value
 	Stack  []*Value
 	Level  int
+	Strict bool
+	Arena  *Arena
+
+	offset     int
+	zero       bool
+	scratch    []byte
+	children   []*Value
+	listStarts []int
+}
+
+// Reset clears the decoder, including any error state, for a new message and
+// resets its Arena, if any, recycling the memory of previously decoded values.
+func (d *Decoder) Reset() {
+	if d.Arena != nil {
+		d.Arena.Reset()
+		clear(d.Values)
+		d.Values = d.Values[:0]
+	} else {
+		d.Values = nil
+	}
+	clear(d.Stack)
+	d.Stack = d.Stack[:0]
+	d.Level = 0
+	d.offset = 0
+	d.zero = false
+	d.scratch = d.scratch[:0]
+	clear(d.children)
+	d.children = d.children[:0]
+	d.listStarts = d.listStarts[:0]
 }
 
+// Collapse is retained for compatibility. Values are attached to their parent
+// as soon as they complete, so the stack never holds a complete value.
 func (d *Decoder) Collapse() {
-	for {
-		l := len(d.Stack)
-		if l == 0 {
-			return
-		}
-		v := d.Stack[l-1]
-		if !v.Complete() {
-			return
-		}
-		d.Stack = d.Stack[:l-1]
-		l--
-		if l == 0 {
-			d.Values = append(d.Values, v)
-			return
-		}
-		d.Stack[l-1].Values = append(d.Stack[l-1].Values, v)
+	for l := len(d.Stack); l > 0 && d.Stack[l-1].Complete(); l = len(d.Stack) {
+		d.pop()
 	}
 }
 
-func (d *Decoder) TerminateString(b byte) {
-	l := len(d.Stack)
-	if l > 0 {
-		v := d.Stack[l-1]
-		if b == 0x01 || !v.String() || len(v.Values) == 0 {
-			return
-		}
-		v.Values = v.Values[:0]
-		d.Stack = d.Stack[:l-1]
-		l--
-		if l == 0 {
-			d.Values = append(d.Values, v)
-		} else {
-			d.Stack[l-1].Values = append(d.Stack[l-1].Values, v)
-		}
-		d.Collapse()
+// TerminateString completes a string whose trailing 0x00 has been read, unless
+// b is the 0x01 that would turn that 0x00 into an escaped null byte.
+func (d *Decoder) TerminateString(b byte) error {
+	if !d.zero || b == 0x01 {
+		return nil
 	}
+	d.zero = false
+	return d.pop()
 }
 
 func (d *Decoder) Accept(b byte) error {
-	d.TerminateString(b)
-	l := len(d.Stack)
-	if l > 0 {
-		v := d.Stack[l-1]
-		if v.String() {
-			if b == 0x00 {
-				v.Values = append(v.Values, nil)
-				return nil
-			}
-			if b == 0x01 && len(v.Values) == 1 {
-				v.Values = v.Values[:0]
-				v.Bytes = append(v.Bytes, 0x00)
-				return nil
+	_, err := d.Write([]byte{b})
+	return err
+}
+
+// Write decodes p, continuing any value left incomplete by earlier calls. On
+// error it returns the number of bytes of p consumed before the offending one.
+func (d *Decoder) Write(p []byte) (int, error) {
+	n, err := d.write(p)
+	d.offset += n
+	return n, err
+}
+
+// Offset returns the number of bytes consumed since the last Reset. After an
+// error it is the position of the offending byte.
+func (d *Decoder) Offset() int {
+	return d.offset
+}
+
+func (d *Decoder) write(p []byte) (int, error) {
+	i := 0
+	for i < len(p) {
+		if l := len(d.Stack); l > 0 {
+			v := d.Stack[l-1]
+			info := codeTable[v.Code]
+			switch info.kind {
+			case kindString:
+				buf := &v.Bytes
+				if d.Arena != nil {
+					buf = &d.scratch
+				}
+				if d.zero {
+					d.zero = false
+					if p[i] == 0x01 {
+						*buf = append(*buf, 0x00)
+						i++
+						continue
+					}
+					if err := d.pop(); err != nil {
+						return i, err
+					}
+					continue
+				}
+				j := bytes.IndexByte(p[i:], 0x00)
+				if j < 0 {
+					*buf = append(*buf, p[i:]...)
+					i = len(p)
+					continue
+				}
+				*buf = append(*buf, p[i:i+j]...)
+				d.zero = true
+				i += j + 1
+				continue
+			case kindBlock:
+				need := int(info.width) - len(v.Bytes)
+				n := min(need, len(p)-i)
+				v.Bytes = append(v.Bytes, p[i:i+n]...)
+				i += n
+				if n == need {
+					d.pop()
+				}
+				continue
+			case kindList:
+				if p[i] == 0x00 {
+					if d.Level != 0 {
+						return i, errors.New("list terminated with non-zero level")
+					}
+					if err := d.pop(); err != nil {
+						return i, err
+					}
+					i++
+					continue
+				}
 			}
-			v.Bytes = append(v.Bytes, b)
-			return nil
 		}
-		if v.Block() {
-			v.Bytes = append(v.Bytes, b)
-			d.Collapse()
-			return nil
+		b := p[i]
+		info := codeTable[b]
+		switch {
+		case info.kind == kindLevel:
+			d.Level++
+			i++
+			continue
+		case info.kind == kindIllegal:
+			return i, errors.New("illegal type code")
+		case d.Level > 0 && b < 0x20:
+			return i, errors.New("illegal level extension")
 		}
-		if b == 0x00 && v.List() {
-			if d.Level != 0 {
-				return errors.New("list terminated with non-zero level")
+		i++
+		v := d.newValue()
+		v.Code, v.Level = b, d.Level
+		d.Level = 0
+		switch info.kind {
+		case kindBlock:
+			if info.width == 0 {
+				d.attach(v)
+				continue
 			}
-			d.Stack = d.Stack[:l-1]
-			l--
-			if l == 0 {
-				d.Values = append(d.Values, v)
+			if d.Arena != nil {
+				v.Bytes = d.Arena.allocBytes(int(info.width))
 			} else {
-				d.Stack[l-1].Values = append(d.Stack[l-1].Values, v)
+				v.Bytes = make([]byte, 0, info.width)
+			}
+		case kindList:
+			if d.Arena != nil {
+				d.listStarts = append(d.listStarts, len(d.children))
 			}
-			d.Collapse()
-			return nil
 		}
+		d.Stack = append(d.Stack, v)
 	}
-	if b == 0xFF {
-		d.Level++
-		return nil
+	return len(p), nil
+}
+
+func (d *Decoder) pop() error {
+	l := len(d.Stack)
+	v := d.Stack[l-1]
+	if d.Arena != nil {
+		switch {
+		case v.String():
+			v.Bytes = d.Arena.copyBytes(d.scratch)
+		case v.List():
+			v.Values = d.Arena.copyValues(d.children[d.listStarts[len(d.listStarts)-1]:])
+		}
 	}
-	if b < 0x08 {
-		return errors.New("illegal type code")
+	if d.Strict {
+		if v.Code == CodeText && !utf8.Valid(v.Bytes) {
+			return errors.New("invalid utf-8 text")
+		}
+		if v.List() && v.Level == 0 {
+			if err := checkSorted(v); err != nil {
+				return err
+			}
+		}
 	}
-	if d.Level > 0 && b < 0x20 {
-		return errors.New("illegal level extension")
+	if d.Arena != nil {
+		switch {
+		case v.String():
+			d.scratch = d.scratch[:0]
+		case v.List():
+			start := d.listStarts[len(d.listStarts)-1]
+			d.listStarts = d.listStarts[:len(d.listStarts)-1]
+			clear(d.children[start:])
+			d.children = d.children[:start]
+		}
 	}
-	d.Stack = append(d.Stack, &Value{Code: b, Level: d.Level})
-	d.Level = 0
-	d.Collapse()
+	d.Stack = d.Stack[:l-1]
+	d.attach(v)
 	return nil
 }
 
-func Decode(bytes []byte) ([]*Value, error) {
-	decoder := Decoder{}
-	for _, b := range bytes {
-		if err := decoder.Accept(b); err != nil {
-			return decoder.Values, err
+func (d *Decoder) newValue() *Value {
+	if d.Arena != nil {
+		return d.Arena.newValue()
+	}
+	return &Value{}
+}
+
+func (d *Decoder) attach(v *Value) {
+	for {
+		l := len(d.Stack)
+		if l == 0 {
+			d.Values = append(d.Values, v)
+			return
+		}
+		p := d.Stack[l-1]
+		info := codeTable[p.Code]
+		if info.kind != kindTuple {
+			if d.Arena != nil {
+				d.children = append(d.children, v)
+			} else {
+				p.Values = append(p.Values, v)
+			}
+			return
 		}
+		if p.Values == nil {
+			if d.Arena != nil {
+				p.Values = d.Arena.allocValues(int(info.arity))
+			} else {
+				p.Values = make([]*Value, 0, info.arity)
+			}
+		}
+		p.Values = append(p.Values, v)
+		if len(p.Values) < int(info.arity) {
+			return
+		}
+		d.Stack = d.Stack[:l-1]
+		v = p
+	}
+}
+
+func Decode(bytes []byte) ([]*Value, error) {
+	return decode(&Decoder{}, bytes)
+}
+
+// DecodeStrict is Decode with additional validation: text strings must be
+// valid UTF-8 and maps and sets must be sorted without duplicates.
+func DecodeStrict(bytes []byte) ([]*Value, error) {
+	return decode(&Decoder{Strict: true}, bytes)
+}
+
+func decode(decoder *Decoder, bytes []byte) ([]*Value, error) {
+	if _, err := decoder.Write(bytes); err != nil {
+		return decoder.Values, err
+	}
+	return decoder.Finish()
+}
+
+// Finish signals the end of input and applies the same checks as Decode: no
+// value may be left incomplete and no level extension pending. The values
+// completed so far are returned even on error, and Pending still reports what
+// was left incomplete.
+func (d *Decoder) Finish() ([]*Value, error) {
+	if err := d.TerminateString(0xFF); err != nil {
+		return d.Values, err
 	}
-	decoder.TerminateString(0xFF)
-	if len(decoder.Stack) != 0 {
-		return nil, errors.New("partial value left on stack")
+	if len(d.Stack) != 0 {
+		return d.Values, errors.New("partial value left on stack")
 	}
-	if decoder.Level != 0 {
-		return nil, errors.New("non zero level")
+	if d.Level != 0 {
+		return d.Values, errors.New("non zero level")
+	}
+	return d.Values, nil
+}
+
+// Pending returns snapshots of the values still being decoded, outermost
+// first, holding the bytes and children read so far. It is meant for
+// reporting what was parsed before an error or the end of input.
+func (d *Decoder) Pending() []*Value {
+	pending := make([]*Value, len(d.Stack))
+	lists := 0
+	for i, v := range d.Stack {
+		c := *v
+		c.Bytes = slices.Clone(v.Bytes)
+		c.Values = slices.Clone(v.Values)
+		if d.Arena != nil {
+			switch {
+			case v.String():
+				c.Bytes = slices.Clone(d.scratch)
+			case v.List():
+				end := len(d.children)
+				if lists+1 < len(d.listStarts) {
+					end = d.listStarts[lists+1]
+				}
+				c.Values = slices.Clone(d.children[d.listStarts[lists]:end])
+				lists++
+			}
+		}
+		pending[i] = &c
 	}
-	return decoder.Values, nil
+	return pending
 }
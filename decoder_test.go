@@ -1,6 +1,7 @@
 package bone
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 )
@@ -754,3 +755,123 @@ func TestDecodableSeedCorpus(t *testing.T) {
 		})
 	}
 }
+
+func TestDecoderFinish(t *testing.T) {
+	cases := []struct {
+		name      string
+		payload   []byte
+		completed int
+		pending   int
+	}{
+		{"complete", []byte{0x20, 0x91, 0x41, 0x00}, 2, 0},
+		{"trailing level", []byte{0x20, 0x21, 0xFF}, 2, 0},
+		{"partial block", []byte{0x20, 0x40, 0xAA}, 1, 1},
+		{"partial string", []byte{0x20, 0xF0, 0x10, 0x91, 0x41}, 1, 2},
+		{"partial tuple", []byte{0x10, 0xC0, 0x20, 0xB0, 0x21}, 1, 2},
+	}
+	for _, arena := range []bool{false, true} {
+		for _, tc := range cases {
+			t.Run(fmt.Sprintf("%s arena=%v", tc.name, arena), func(t *testing.T) {
+				d := &Decoder{}
+				if arena {
+					d.Arena = &Arena{}
+				}
+				if _, err := d.Write(tc.payload); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				values, err := d.Finish()
+				if (err == nil) != (tc.name == "complete") {
+					t.Errorf("Unexpected error result: %v", err)
+				}
+				if len(values) != tc.completed {
+					t.Errorf("Expected %d completed values, got %d", tc.completed, len(values))
+				}
+				if pending := d.Pending(); len(pending) != tc.pending {
+					t.Errorf("Expected %d pending values, got %d", tc.pending, len(pending))
+				}
+			})
+		}
+	}
+}
+
+func TestDecoderPending(t *testing.T) {
+	for _, arena := range []bool{false, true} {
+		d := &Decoder{}
+		if arena {
+			d.Arena = &Arena{}
+		}
+		if _, err := d.Write([]byte{0xF0, 0x10, 0x11, 0xF1, 0x12, 0x91, 0x41, 0x00, 0x01, 0x42}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := d.Finish(); err == nil {
+			t.Fatalf("Expected partial value error")
+		}
+		pending := d.Pending()
+		if len(pending) != 3 {
+			t.Fatalf("Expected 3 pending values, got %d", len(pending))
+		}
+		if len(pending[0].Values) != 2 || len(pending[1].Values) != 1 {
+			t.Errorf("Expected 2 and 1 children, got %d and %d", len(pending[0].Values), len(pending[1].Values))
+		}
+		if string(pending[2].Bytes) != "A\x00B" {
+			t.Errorf("Expected partial string %q, got %q", "A\x00B", pending[2].Bytes)
+		}
+
+		d.Reset()
+		n, err := d.Write([]byte{0x20, 0x21, 0x03})
+		if err == nil || n != 2 || d.Offset() != 2 {
+			t.Errorf("Expected illegal type code error at offset 2, got %d (%d), %v", n, d.Offset(), err)
+		}
+
+		d.Reset()
+		values, err := decode(d, []byte{0x21})
+		if err != nil || len(values) != 1 || values[0].Code != 0x21 || d.Offset() != 1 {
+			t.Errorf("Expected decoder to be reusable after Reset, got %v, %v", values, err)
+		}
+	}
+}
+
+func TestDecodeReturnsCompletedValues(t *testing.T) {
+	values, err := Decode([]byte{0x20, 0x21, 0xFF})
+	if err == nil {
+		t.Fatalf("Expected trailing level error")
+	}
+	if len(values) != 2 {
+		t.Errorf("Expected 2 completed values, got %d", len(values))
+	}
+}
+
+func FuzzDecodeMatchesLegacy(f *testing.F) {
+	for _, data := range DecodableSeedCorpus {
+		f.Add(data, uint8(3))
+	}
+	f.Fuzz(func(t *testing.T, payload []byte, chunk uint8) {
+		want, wantErr := legacyDecode(payload)
+		got, gotErr := Decode(payload)
+		if (wantErr == nil) != (gotErr == nil) {
+			t.Fatalf("legacy error %v, table error %v", wantErr, gotErr)
+		}
+		if gotErr != nil {
+			// Decode returns the values completed before an error, which the
+			// legacy decoder drops, so only successful outputs are compared.
+			return
+		}
+		if !bytes.Equal(Encode(want), Encode(got)) {
+			t.Fatalf("legacy and table decoders disagree")
+		}
+
+		d := &Decoder{}
+		size := int(chunk)%7 + 1
+		for i := 0; i < len(payload); i += size {
+			if _, err := d.Write(payload[i:min(i+size, len(payload))]); err != nil {
+				t.Fatalf("chunked decode failed: %v", err)
+			}
+		}
+		if err := d.TerminateString(0xFF); err != nil {
+			t.Fatalf("chunked decode failed: %v", err)
+		}
+		if !bytes.Equal(Encode(d.Values), payload) {
+			t.Fatalf("chunked decode does not round trip")
+		}
+	})
+}
@@ -1,47 +1,83 @@
 package bone
 
+import "bytes"
+
 type StackItem struct {
 	v *Value
 	i int
 }
 
 func Encode(values []*Value) []byte {
-	res := []byte{}
-	stack := []*StackItem{}
-	l := 0
-	for _, top := range values {
-		stack = append(stack, &StackItem{v: top})
-		l++
-		for l > 0 {
-			s := stack[l-1]
-			if s.i == 0 {
-				for range s.v.Level {
-					res = append(res, 0xFF)
-				}
-				res = append(res, s.v.Code)
+	n := 0
+	for _, v := range values {
+		n += EncodedLen(v)
+	}
+	res := make([]byte, 0, n)
+	for _, v := range values {
+		res = AppendEncode(res, v)
+	}
+	return res
+}
+
+// AppendEncode appends the encoding of v to dst and returns the extended
+// buffer.
+func AppendEncode(dst []byte, v *Value) []byte {
+	stack := []*StackItem{{v: v}}
+	l := 1
+	for l > 0 {
+		s := stack[l-1]
+		if s.i == 0 {
+			for range s.v.Level {
+				dst = append(dst, 0xFF)
 			}
-			if s.v.String() {
-				for _, b := range s.v.Bytes {
-					res = append(res, b)
-					if b == 0x00 {
-						res = append(res, 0x01)
-					}
+			dst = append(dst, s.v.Code)
+		}
+		if s.v.String() {
+			for _, b := range s.v.Bytes {
+				dst = append(dst, b)
+				if b == 0x00 {
+					dst = append(dst, 0x01)
 				}
-			} else if s.v.Block() {
-				res = append(res, s.v.Bytes...)
 			}
-			if s.i < len(s.v.Values) {
-				stack = append(stack, &StackItem{v: s.v.Values[s.i]})
-				l++
-				s.i++
-			} else {
-				if s.v.List() || s.v.String() {
-					res = append(res, 0x00)
-				}
-				stack = stack[:l-1]
-				l--
+		} else if s.v.Block() {
+			dst = append(dst, s.v.Bytes...)
+		}
+		if s.i < len(s.v.Values) {
+			stack = append(stack, &StackItem{v: s.v.Values[s.i]})
+			l++
+			s.i++
+		} else {
+			if s.v.List() || s.v.String() {
+				dst = append(dst, 0x00)
 			}
+			stack = stack[:l-1]
+			l--
 		}
 	}
-	return res
+	return dst
+}
+
+// EncodedLen returns the exact number of bytes AppendEncode writes for v:
+// level prefixes, type codes, block bytes, escaped string bytes and string and
+// list terminators.
+func EncodedLen(v *Value) int {
+	n := v.Level + 1
+	if v.String() {
+		n += len(v.Bytes) + bytes.Count(v.Bytes, []byte{0x00}) + 1
+	} else if v.Block() {
+		n += len(v.Bytes)
+	}
+	for _, c := range v.Values {
+		n += EncodedLen(c)
+	}
+	if v.List() {
+		n++
+	}
+	return n
+}
+
+// Compare orders values by their encoded bytes, the order BONE is designed to
+// preserve.
+func Compare(a, b *Value) int {
+	return bytes.Compare(AppendEncode(nil, a), AppendEncode(nil, b))
 }
@@ -21,5 +21,38 @@ func FuzzEncode(f *testing.F) {
 		if !bytes.Equal(Encode(values), payload) {
 			t.Errorf("re-encoded bytes do not match the original payload")
 		}
+		n := 0
+		for _, v := range values {
+			n += EncodedLen(v)
+		}
+		if n != len(payload) {
+			t.Errorf("encoded length %d does not match the payload length %d", n, len(payload))
+		}
 	})
 }
+
+func TestAppendEncode(t *testing.T) {
+	for i, data := range DecodableSeedCorpus {
+		values, err := Decode(data)
+		if err != nil {
+			t.Fatalf("Failed to decode seed corpus item %d: %v", i, err)
+		}
+		prefix := []byte{0xAB, 0xCD}
+		dst := make([]byte, len(prefix), len(prefix)+len(data))
+		copy(dst, prefix)
+		n := 0
+		for _, v := range values {
+			n += EncodedLen(v)
+			dst = AppendEncode(dst, v)
+		}
+		if n != len(data) {
+			t.Errorf("Seed corpus item %d: expected encoded length %d, got %d", i, len(data), n)
+		}
+		if !bytes.Equal(dst[:2], prefix) || !bytes.Equal(dst[2:], data) {
+			t.Errorf("Seed corpus item %d: expected % X after prefix, got % X", i, data, dst)
+		}
+		if cap(dst) != len(prefix)+len(data) {
+			t.Errorf("Seed corpus item %d: expected pre-sized buffer to be reused", i)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package bone
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// NewFloat returns a B8 (code 0x70) holding the IEEE 754 bits of f,
+// big-endian, with all bits flipped for negative values and only the sign bit
+// otherwise, so encoded floats sort in numeric order.
+func NewFloat(f float64) *Value {
+	return &Value{Code: CodeFloat, Bytes: binary.BigEndian.AppendUint64(nil, floatBits(f))}
+}
+
+func floatBits(f float64) uint64 {
+	b := math.Float64bits(f)
+	if b>>63 == 1 {
+		return ^b
+	}
+	return b | 1<<63
+}
+
+func floatFromBits(b uint64) float64 {
+	if b>>63 == 1 {
+		return math.Float64frombits(b &^ (1 << 63))
+	}
+	return math.Float64frombits(^b)
+}
+
+func (v *Value) Float() (float64, error) {
+	if v.Code != CodeFloat || len(v.Bytes) != 8 {
+		return 0, errors.New("not a float")
+	}
+	return floatFromBits(binary.BigEndian.Uint64(v.Bytes)), nil
+}
@@ -0,0 +1,144 @@
+// Package frame wraps encoded BONE values in checksummed frames for log files.
+//
+// Each frame is a 4 byte big-endian payload length, the payload (the
+// encoding of exactly one value) and a 4 byte big-endian CRC-32C of the length
+// and payload together. A frame that is cut short or fails its checksum at the
+// very end of the input is reported as ErrTorn, the signature of a write that
+// did not fully reach the disk.
+package frame
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+const (
+	HeaderSize  = 4
+	TrailerSize = 4
+	// DefaultMaxSize bounds the payload length a Writer writes and a Reader
+	// accepts, so a corrupt length cannot trigger a huge allocation.
+	DefaultMaxSize = 64 << 20
+)
+
+var (
+	ErrTorn     = errors.New("frame: torn write at end of input")
+	ErrChecksum = errors.New("frame: checksum mismatch")
+	ErrTooLarge = errors.New("frame: payload exceeds maximum size")
+)
+
+var table = crc32.MakeTable(crc32.Castagnoli)
+
+// Append appends the frame holding v to dst. It does not check the payload
+// size: a payload over a Reader's MaxSize, or of 4 GiB or more, makes a frame
+// that cannot be read back. Writer checks it.
+func Append(dst []byte, v *bone.Value) []byte {
+	start := len(dst)
+	dst = append(dst, 0, 0, 0, 0)
+	dst = bone.AppendEncode(dst, v)
+	binary.BigEndian.PutUint32(dst[start:], uint32(len(dst)-start-HeaderSize))
+	return binary.BigEndian.AppendUint32(dst, crc32.Checksum(dst[start:], table))
+}
+
+type Writer struct {
+	MaxSize int
+
+	w   io.Writer
+	buf []byte
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{MaxSize: DefaultMaxSize, w: w}
+}
+
+// Write writes the frame holding v with a single call to the underlying
+// writer. A payload over MaxSize is rejected with ErrTooLarge before anything
+// is written.
+func (w *Writer) Write(v *bone.Value) error {
+	if n := bone.EncodedLen(v); n > w.MaxSize || n > math.MaxUint32 {
+		return ErrTooLarge
+	}
+	w.buf = Append(w.buf[:0], v)
+	_, err := w.w.Write(w.buf)
+	return err
+}
+
+type Reader struct {
+	MaxSize int
+
+	r      *bufio.Reader
+	buf    []byte
+	offset int64
+	err    error
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{MaxSize: DefaultMaxSize, r: bufio.NewReader(r)}
+}
+
+// Offset returns the position of the next frame, which after an error is the
+// start of the offending frame: the length to truncate a torn file to.
+func (r *Reader) Offset() int64 {
+	return r.offset
+}
+
+// Next reads and verifies the next frame. It returns io.EOF when the input
+// ends cleanly between frames. Any other error is final: it is returned again
+// by every later call and Offset stays at the start of the offending frame.
+func (r *Reader) Next() (*bone.Value, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var header [HeaderSize]byte
+	_, err := io.ReadFull(r.r, header[:])
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, r.fail(ErrTorn)
+	}
+	size := int(binary.BigEndian.Uint32(header[:]))
+	if size > r.MaxSize {
+		if _, err := r.r.Peek(1); err != nil {
+			return nil, r.fail(ErrTorn)
+		}
+		return nil, r.fail(ErrTooLarge)
+	}
+	if cap(r.buf) < HeaderSize+size+TrailerSize {
+		r.buf = make([]byte, HeaderSize+size+TrailerSize)
+	}
+	frame := r.buf[:HeaderSize+size+TrailerSize]
+	copy(frame, header[:])
+	if _, err := io.ReadFull(r.r, frame[HeaderSize:]); err != nil {
+		return nil, r.fail(ErrTorn)
+	}
+	sum := binary.BigEndian.Uint32(frame[HeaderSize+size:])
+	if crc32.Checksum(frame[:HeaderSize+size], table) != sum {
+		if _, err := r.r.Peek(1); err == io.EOF {
+			return nil, r.fail(ErrTorn)
+		}
+		return nil, r.fail(ErrChecksum)
+	}
+	values, err := bone.Decode(frame[HeaderSize : HeaderSize+size])
+	if err != nil {
+		r.err = fmt.Errorf("frame: invalid payload at offset %d: %w", r.offset, err)
+		return nil, r.err
+	}
+	if len(values) != 1 {
+		r.err = fmt.Errorf("frame: payload at offset %d holds %d values", r.offset, len(values))
+		return nil, r.err
+	}
+	r.offset += int64(len(frame))
+	return values[0], nil
+}
+
+func (r *Reader) fail(err error) error {
+	r.err = fmt.Errorf("%w at offset %d", err, r.offset)
+	return r.err
+}
@@ -0,0 +1,128 @@
+package frame
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"testing"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+func testValues() []*bone.Value {
+	return []*bone.Value{
+		bone.List(bone.Str("a"), bone.Int(1)),
+		bone.Raw([]byte{0x00, 0x00, 0x01}),
+		bone.Tuple(bone.Str("b"), bone.Int(-2)),
+	}
+}
+
+func writeAll(t *testing.T) ([]byte, []int) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	var offsets []int
+	for _, v := range testValues() {
+		offsets = append(offsets, buf.Len())
+		if err := w.Write(v); err != nil {
+			t.Fatalf("Failed to write frame: %v", err)
+		}
+	}
+	return buf.Bytes(), offsets
+}
+
+func TestRoundTrip(t *testing.T) {
+	data, _ := writeAll(t)
+	r := NewReader(bytes.NewReader(data))
+	for i, want := range testValues() {
+		v, err := r.Next()
+		if err != nil {
+			t.Fatalf("Frame %d: unexpected error: %v", i, err)
+		}
+		if bone.Compare(v, want) != 0 {
+			t.Errorf("Frame %d: value mismatch", i)
+		}
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+	if r.Offset() != int64(len(data)) {
+		t.Errorf("Expected offset %d, got %d", len(data), r.Offset())
+	}
+}
+
+func TestTornTail(t *testing.T) {
+	data, offsets := writeAll(t)
+	last := offsets[len(offsets)-1]
+	for cut := last + 1; cut < len(data); cut++ {
+		r := NewReader(bytes.NewReader(data[:cut]))
+		var err error
+		for err == nil {
+			_, err = r.Next()
+		}
+		if !errors.Is(err, ErrTorn) {
+			t.Fatalf("Cut at %d: expected ErrTorn, got %v", cut, err)
+		}
+		if r.Offset() != int64(last) {
+			t.Errorf("Cut at %d: expected offset %d, got %d", cut, last, r.Offset())
+		}
+	}
+
+	garbled := bytes.Clone(data)
+	garbled[len(garbled)-2] ^= 0xFF
+	r := NewReader(bytes.NewReader(garbled))
+	var err error
+	for err == nil {
+		_, err = r.Next()
+	}
+	if !errors.Is(err, ErrTorn) {
+		t.Errorf("Expected bad checksum on the final frame to be ErrTorn, got %v", err)
+	}
+}
+
+func TestCorruption(t *testing.T) {
+	data, offsets := writeAll(t)
+	corrupt := bytes.Clone(data)
+	corrupt[offsets[1]+HeaderSize] ^= 0x01
+	r := NewReader(bytes.NewReader(corrupt))
+	if _, err := r.Next(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := r.Next(); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Expected ErrChecksum, got %v", err)
+	}
+	if _, err := r.Next(); !errors.Is(err, ErrChecksum) || r.Offset() != int64(offsets[1]) {
+		t.Errorf("Expected ErrChecksum again at %d, got %v at %d", offsets[1], err, r.Offset())
+	}
+
+	r = NewReader(bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x00}))
+	if _, err := r.Next(); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+
+	bad := []byte{0x00, 0x00, 0x00, 0x01, 0x03}
+	bad = binary.BigEndian.AppendUint32(bad, crc32.Checksum(bad, table))
+	r = NewReader(bytes.NewReader(bad))
+	if _, err := r.Next(); err == nil || errors.Is(err, ErrChecksum) {
+		t.Errorf("Expected invalid payload error, got %v", err)
+	}
+	if _, err := r.Next(); err == nil || r.Offset() != 0 {
+		t.Errorf("Expected the payload error to be final, got %v at %d", err, r.Offset())
+	}
+}
+
+func TestWriteTooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.MaxSize = 8
+	if err := w.Write(bone.Raw([]byte("abcdefg"))); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing written, got %d bytes", buf.Len())
+	}
+	if err := w.Write(bone.Raw([]byte("abcdef"))); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+// Package frontcode compresses sorted sequences of encoded BONE keys by
+// storing each key as the length of the prefix it shares with the previous
+// key and the remaining suffix.
+//
+// Every Restart-th key is stored whole and its offset recorded, so a key can
+// be found by binary searching the restart points and decoding forward from
+// the nearest one. The layout is the entries, each a uvarint shared length, a
+// uvarint suffix length and the suffix, followed by the restart offsets and
+// the restart interval and key count, all as 4 byte big-endian integers.
+//
+// Keys come back byte for byte, so bone.Decode and the byte order of
+// bone.Compare apply to them unchanged.
+package frontcode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+)
+
+// DefaultRestart is the restart interval used by NewBuilder.
+const DefaultRestart = 16
+
+var (
+	ErrOutOfOrder = errors.New("frontcode: keys must be added in sorted order")
+	ErrCorrupt    = errors.New("frontcode: corrupt data")
+)
+
+type Builder struct {
+	// Restart is the number of keys between restart points. Zero or less
+	// means DefaultRestart.
+	Restart int
+
+	buf      []byte
+	restarts []uint32
+	last     []byte
+	n        int
+}
+
+func NewBuilder() *Builder {
+	return &Builder{Restart: DefaultRestart}
+}
+
+// Add appends key, which must not sort before the previous key.
+func (b *Builder) Add(key []byte) error {
+	if b.n > 0 && bytes.Compare(key, b.last) < 0 {
+		return ErrOutOfOrder
+	}
+	shared := 0
+	if b.n%b.restart() == 0 {
+		b.restarts = append(b.restarts, uint32(len(b.buf)))
+	} else {
+		for shared < min(len(key), len(b.last)) && key[shared] == b.last[shared] {
+			shared++
+		}
+	}
+	b.buf = binary.AppendUvarint(b.buf, uint64(shared))
+	b.buf = binary.AppendUvarint(b.buf, uint64(len(key)-shared))
+	b.buf = append(b.buf, key[shared:]...)
+	b.last = append(b.last[:0], key...)
+	b.n++
+	return nil
+}
+
+func (b *Builder) restart() int {
+	if b.Restart <= 0 {
+		return DefaultRestart
+	}
+	return b.Restart
+}
+
+// Len returns the number of keys added.
+func (b *Builder) Len() int {
+	return b.n
+}
+
+// Finish returns the encoded keys. The builder must not be used afterwards.
+func (b *Builder) Finish() []byte {
+	for _, r := range b.restarts {
+		b.buf = binary.BigEndian.AppendUint32(b.buf, r)
+	}
+	b.buf = binary.BigEndian.AppendUint32(b.buf, uint32(b.restart()))
+	return binary.BigEndian.AppendUint32(b.buf, uint32(b.n))
+}
+
+// Reader gives random access to front-coded keys without decoding them all.
+type Reader struct {
+	data     []byte
+	restarts []uint32
+	restart  int
+	n        int
+}
+
+// NewReader checks the structure of data, as returned by Builder.Finish, and
+// returns a reader over it. The reader refers to data without copying it.
+func NewReader(data []byte) (*Reader, error) {
+	if len(data) < 8 {
+		return nil, ErrCorrupt
+	}
+	r := &Reader{
+		restart: int(binary.BigEndian.Uint32(data[len(data)-8:])),
+		n:       int(binary.BigEndian.Uint32(data[len(data)-4:])),
+	}
+	if r.restart <= 0 {
+		return nil, ErrCorrupt
+	}
+	count := (r.n + r.restart - 1) / r.restart
+	end := len(data) - 8 - 4*count
+	if end < 0 {
+		return nil, ErrCorrupt
+	}
+	for i := range count {
+		r.restarts = append(r.restarts, binary.BigEndian.Uint32(data[end+4*i:]))
+	}
+	r.data = data[:end]
+
+	off, prev := 0, 0
+	for i := range r.n {
+		if i%r.restart == 0 && r.restarts[i/r.restart] != uint32(off) {
+			return nil, ErrCorrupt
+		}
+		shared, suffix, next, ok := r.entry(off)
+		if !ok || shared > prev || (i%r.restart == 0 && shared != 0) {
+			return nil, ErrCorrupt
+		}
+		off, prev = next, shared+len(suffix)
+	}
+	if off != end {
+		return nil, ErrCorrupt
+	}
+	return r, nil
+}
+
+// entry parses the entry at off, returning its shared length, its suffix and
+// the offset of the following entry.
+func (r *Reader) entry(off int) (int, []byte, int, bool) {
+	shared, n := binary.Uvarint(r.data[off:])
+	if n <= 0 || shared > uint64(len(r.data)) {
+		return 0, nil, 0, false
+	}
+	off += n
+	length, n := binary.Uvarint(r.data[off:])
+	if n <= 0 || length > uint64(len(r.data)-off-n) {
+		return 0, nil, 0, false
+	}
+	off += n
+	return int(shared), r.data[off : off+int(length)], off + int(length), true
+}
+
+// Len returns the number of keys.
+func (r *Reader) Len() int {
+	return r.n
+}
+
+// Key returns a copy of the i-th key.
+func (r *Reader) Key(i int) []byte {
+	if i < 0 || i >= r.n {
+		panic("frontcode: key index out of range")
+	}
+	var key []byte
+	off := int(r.restarts[i/r.restart])
+	for range i%r.restart + 1 {
+		shared, suffix, next, _ := r.entry(off)
+		key = append(key[:shared], suffix...)
+		off = next
+	}
+	return key
+}
+
+// Search returns the index of the first key that is not less than key and
+// whether it is equal to key. The index is Len() if every key is less.
+func (r *Reader) Search(key []byte) (int, bool) {
+	// Find the last restart whose key is less than key; the answer lies in
+	// its run or is the restart after it.
+	lo, hi := 0, len(r.restarts)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		_, first, _, _ := r.entry(int(r.restarts[m]))
+		if bytes.Compare(first, key) < 0 {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	if lo == 0 {
+		if r.n == 0 {
+			return 0, false
+		}
+		_, first, _, _ := r.entry(0)
+		return 0, bytes.Equal(first, key)
+	}
+	i := (lo - 1) * r.restart
+	off := int(r.restarts[lo-1])
+	var cur []byte
+	for ; i < r.n; i++ {
+		shared, suffix, next, _ := r.entry(off)
+		cur = append(cur[:shared], suffix...)
+		if c := bytes.Compare(cur, key); c >= 0 {
+			return i, c == 0
+		}
+		off = next
+	}
+	return r.n, false
+}
+
+// Decode returns all keys of data, as returned by Builder.Finish.
+func Decode(data []byte) ([][]byte, error) {
+	r, err := NewReader(data)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([][]byte, 0, r.n)
+	var cur []byte
+	for off := 0; off < len(r.data); {
+		shared, suffix, next, _ := r.entry(off)
+		cur = append(cur[:shared], suffix...)
+		keys = append(keys, slices.Clone(cur))
+		off = next
+	}
+	return keys, nil
+}
@@ -0,0 +1,120 @@
+package frontcode
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+func testKeys() [][]byte {
+	var keys [][]byte
+	for _, tenant := range []string{"acme", "globex", "initech"} {
+		for i := range 40 {
+			keys = append(keys, bone.Encode([]*bone.Value{bone.List(bone.Str(tenant), bone.Str("orders"), bone.Int(int64(i*3)))}))
+		}
+	}
+	slices.SortFunc(keys, bytes.Compare)
+	return keys
+}
+
+func build(t *testing.T, keys [][]byte, restart int) []byte {
+	b := NewBuilder()
+	b.Restart = restart
+	for _, k := range keys {
+		if err := b.Add(k); err != nil {
+			t.Fatalf("Failed to add key: %v", err)
+		}
+	}
+	return b.Finish()
+}
+
+func TestRoundTrip(t *testing.T) {
+	keys := testKeys()
+	size := 0
+	for _, k := range keys {
+		size += len(k)
+	}
+	for _, restart := range []int{-1, 0, 1, 3, 16, 1000} {
+		data := build(t, keys, restart)
+		if restart > 1 && len(data) >= size {
+			t.Errorf("Restart %d: expected compression, got %d bytes for %d", restart, len(data), size)
+		}
+		decoded, err := Decode(data)
+		if err != nil {
+			t.Fatalf("Restart %d: unexpected error: %v", restart, err)
+		}
+		if !slices.EqualFunc(decoded, keys, bytes.Equal) {
+			t.Fatalf("Restart %d: decoded keys differ", restart)
+		}
+		r, _ := NewReader(data)
+		for i, k := range keys {
+			if !bytes.Equal(r.Key(i), k) {
+				t.Fatalf("Restart %d: key %d differs", restart, i)
+			}
+		}
+	}
+}
+
+func TestZeroBuilder(t *testing.T) {
+	keys := testKeys()
+	var b Builder
+	for _, k := range keys {
+		if err := b.Add(k); err != nil {
+			t.Fatalf("Failed to add key: %v", err)
+		}
+	}
+	if !bytes.Equal(b.Finish(), build(t, keys, DefaultRestart)) {
+		t.Error("Expected a zero Builder to use DefaultRestart")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	keys := testKeys()
+	r, err := NewReader(build(t, keys, 4))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, tenant := range []string{"", "acme", "globex", "initech", "zzz"} {
+		for i := range 122 {
+			k := bone.Encode([]*bone.Value{bone.List(bone.Str(tenant), bone.Str("orders"), bone.Int(int64(i)))})
+			want, wantFound := slices.BinarySearchFunc(keys, k, bytes.Compare)
+			got, found := r.Search(k)
+			if got != want || found != wantFound {
+				t.Fatalf("Search %s %d: expected %d %v, got %d %v", tenant, i, want, wantFound, got, found)
+			}
+		}
+	}
+	empty, _ := NewReader(NewBuilder().Finish())
+	if i, found := empty.Search([]byte{0x10}); i != 0 || found {
+		t.Errorf("Expected 0 false for empty reader, got %d %v", i, found)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	b := NewBuilder()
+	b.Add([]byte{0x12})
+	if err := b.Add([]byte{0x11}); err != ErrOutOfOrder {
+		t.Errorf("Expected ErrOutOfOrder, got %v", err)
+	}
+	if err := b.Add([]byte{0x12}); err != nil {
+		t.Errorf("Expected equal keys to be accepted, got %v", err)
+	}
+
+	data := build(t, testKeys(), 8)
+	for i := range len(data) {
+		corrupt := bytes.Clone(data)
+		corrupt[i] ^= 0xFF
+		if r, err := NewReader(corrupt); err == nil {
+			for j := range r.Len() {
+				r.Key(j)
+			}
+		}
+	}
+	for _, cut := range []int{0, 7, len(data) - 1} {
+		if _, err := NewReader(data[:cut]); err == nil {
+			t.Errorf("Expected error for data cut at %d", cut)
+		}
+	}
+}
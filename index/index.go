@@ -0,0 +1,232 @@
+// Package index maintains secondary indexes over records kept in an ordered
+// key/value Store.
+//
+// A Table stores each record under the key list(table, pk) and, for every
+// Index the record belongs to, an empty entry under list(index, components...,
+// pk). Because a list encodes as its elements in order, the entries of an
+// index sort by their components and a lookup by any leading components is a
+// single range scan.
+//
+// Index entries are written before a record and removed after it, and lookups
+// check each entry against the record it points to. Without a transactional
+// Store a failure part way through Put or Delete can therefore leave stale
+// entries behind, but they are never returned.
+package index
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+type Index struct {
+	// Name identifies the index in the store; it must not be shared with a
+	// table or another index.
+	Name string
+	// Key returns the components a record is indexed under, or nil to leave
+	// the record out of the index.
+	Key func(record *bone.Value) []*bone.Value
+}
+
+type Table struct {
+	store   Store
+	name    string
+	indexes []Index
+}
+
+// NewTable returns the table name in store, maintaining the given indexes.
+func NewTable(store Store, name string, indexes ...Index) (*Table, error) {
+	names := map[string]bool{name: true}
+	for _, idx := range indexes {
+		if names[idx.Name] {
+			return nil, fmt.Errorf("index: duplicate name %q", idx.Name)
+		}
+		names[idx.Name] = true
+	}
+	return &Table{store: store, name: name, indexes: indexes}, nil
+}
+
+func encode(v *bone.Value) []byte {
+	return bone.Encode([]*bone.Value{v})
+}
+
+func (t *Table) recordKey(pk *bone.Value) []byte {
+	return encode(bone.List(bone.Str(t.name), pk))
+}
+
+// entryKeys returns the index entry keys of record, one per index it belongs
+// to.
+func (t *Table) entryKeys(pk, record *bone.Value) [][]byte {
+	var keys [][]byte
+	for _, idx := range t.indexes {
+		components := idx.Key(record)
+		if components == nil {
+			continue
+		}
+		elems := append([]*bone.Value{bone.Str(idx.Name)}, components...)
+		keys = append(keys, encode(bone.List(append(elems, pk)...)))
+	}
+	return keys
+}
+
+func (t *Table) Get(pk *bone.Value) (*bone.Value, bool, error) {
+	return t.get(t.recordKey(pk))
+}
+
+func (t *Table) get(key []byte) (*bone.Value, bool, error) {
+	data, ok, err := t.store.Get(key)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	values, err := bone.Decode(data)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(values) != 1 {
+		return nil, false, errors.New("index: stored record is not a single value")
+	}
+	return values[0], true, nil
+}
+
+// Put stores record under pk, replacing any previous record and updating
+// the index entries that changed.
+func (t *Table) Put(pk, record *bone.Value) error {
+	key := t.recordKey(pk)
+	old, ok, err := t.get(key)
+	if err != nil {
+		return err
+	}
+	keys := t.entryKeys(pk, record)
+	for _, k := range keys {
+		if err := t.store.Put(k, nil); err != nil {
+			return err
+		}
+	}
+	if err := t.store.Put(key, encode(record)); err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	for _, k := range t.entryKeys(pk, old) {
+		if !contains(keys, k) {
+			if err := t.store.Delete(k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Delete removes the record under pk, if any, and its index entries.
+func (t *Table) Delete(pk *bone.Value) error {
+	key := t.recordKey(pk)
+	old, ok, err := t.get(key)
+	if err != nil || !ok {
+		return err
+	}
+	if err := t.store.Delete(key); err != nil {
+		return err
+	}
+	for _, k := range t.entryKeys(pk, old) {
+		if err := t.store.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func contains(keys [][]byte, key []byte) bool {
+	for _, k := range keys {
+		if bytes.Equal(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup returns the records whose components in the named index start with
+// prefix, ordered by their components and then primary key.
+func (t *Table) Lookup(index string, prefix ...*bone.Value) (*Iterator, error) {
+	found := false
+	for _, idx := range t.indexes {
+		found = found || idx.Name == index
+	}
+	if !found {
+		return nil, fmt.Errorf("index: no index %q", index)
+	}
+	// The encoded list without its terminator is a prefix of every entry key
+	// whose leading elements are these.
+	p := encode(bone.List(append([]*bone.Value{bone.Str(index)}, prefix...)...))
+	p = p[:len(p)-1]
+	return &Iterator{t: t, prefix: p, it: t.store.Scan(p, successor(p))}, nil
+}
+
+// successor returns the smallest key greater than every key starting with p,
+// or nil if there is none.
+func successor(p []byte) []byte {
+	s := bytes.Clone(p)
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] != 0xFF {
+			s[i]++
+			return s[:i+1]
+		}
+	}
+	return nil
+}
+
+// Iterator steps through the results of a Lookup.
+type Iterator struct {
+	t      *Table
+	prefix []byte
+	it     bone.Iterator
+	pk     *bone.Value
+	record *bone.Value
+	err    error
+}
+
+func (it *Iterator) Next() bool {
+	for it.err == nil && it.it.Next() {
+		key := it.it.Key()
+		// A 0x01 after the prefix continues a string the prefix ends with
+		// rather than starting the next element.
+		if len(key) > len(it.prefix) && key[len(it.prefix)] == 0x01 {
+			continue
+		}
+		values, err := bone.Decode(key)
+		if err != nil || len(values) != 1 || len(values[0].Values) < 2 {
+			it.err = errors.New("index: invalid entry key")
+			return false
+		}
+		pk := values[0].Values[len(values[0].Values)-1]
+		record, ok, err := it.t.Get(pk)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if !ok || !contains(it.t.entryKeys(pk, record), key) {
+			continue
+		}
+		it.pk, it.record = pk, record
+		return true
+	}
+	if it.err == nil {
+		it.err = it.it.Err()
+	}
+	return false
+}
+
+// PK returns the primary key of the current record.
+func (it *Iterator) PK() *bone.Value {
+	return it.pk
+}
+
+func (it *Iterator) Record() *bone.Value {
+	return it.record
+}
+
+func (it *Iterator) Err() error {
+	return it.err
+}
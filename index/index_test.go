@@ -0,0 +1,120 @@
+package index
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+func user(name, city string, age int64) *bone.Value {
+	return bone.Tuple(bone.Str(name), bone.Str(city), bone.Int(age))
+}
+
+func newUsers(t *testing.T, store Store) *Table {
+	table, err := NewTable(store, "users",
+		Index{Name: "users_by_city_age", Key: func(r *bone.Value) []*bone.Value {
+			return []*bone.Value{r.Values[1], r.Values[2]}
+		}},
+		Index{Name: "users_adults", Key: func(r *bone.Value) []*bone.Value {
+			if age, _ := r.Values[2].Int(); age < 18 {
+				return nil
+			}
+			return []*bone.Value{}
+		}},
+	)
+	if err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	return table
+}
+
+func lookup(t *testing.T, table *Table, index string, prefix ...*bone.Value) string {
+	it, err := table.Lookup(index, prefix...)
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+	var names []string
+	for it.Next() {
+		name, _ := it.Record().Values[0].Text()
+		names = append(names, name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return fmt.Sprint(names)
+}
+
+func TestTable(t *testing.T) {
+	store := &MemStore{}
+	users := newUsers(t, store)
+	put := func(pk int64, r *bone.Value) {
+		if err := users.Put(bone.Int(pk), r); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	put(1, user("ann", "Oslo", 34))
+	put(2, user("bob", "Bergen", 17))
+	put(3, user("cat", "Oslo", 21))
+	put(4, user("dan", "Oslo\x00x", 40))
+	put(5, user("eve", "Oslo", 21))
+
+	cases := []struct {
+		index    string
+		prefix   []*bone.Value
+		expected string
+	}{
+		{"users_by_city_age", nil, "[bob dan cat eve ann]"},
+		{"users_by_city_age", []*bone.Value{bone.Str("Oslo")}, "[cat eve ann]"},
+		{"users_by_city_age", []*bone.Value{bone.Str("Oslo"), bone.Int(21)}, "[cat eve]"},
+		{"users_by_city_age", []*bone.Value{bone.Str("Os")}, "[]"},
+		{"users_adults", nil, "[ann cat dan eve]"},
+	}
+	for _, c := range cases {
+		if got := lookup(t, users, c.index, c.prefix...); got != c.expected {
+			t.Errorf("Lookup %s %v: expected %s, got %s", c.index, c.prefix, c.expected, got)
+		}
+	}
+
+	put(3, user("cat", "Bergen", 22))
+	put(2, user("bob", "Bergen", 18))
+	if err := users.Delete(bone.Int(1)); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if got := lookup(t, users, "users_by_city_age", bone.Str("Oslo")); got != "[eve]" {
+		t.Errorf("Expected [eve] after updates, got %s", got)
+	}
+	if got := lookup(t, users, "users_adults"); got != "[bob cat dan eve]" {
+		t.Errorf("Expected [bob cat dan eve] after updates, got %s", got)
+	}
+	if _, ok, _ := users.Get(bone.Int(1)); ok {
+		t.Error("Expected deleted record to be gone")
+	}
+	// 4 records and 4 + 4 index entries.
+	if n := len(store.entries); n != 12 {
+		t.Errorf("Expected 12 store entries, got %d", n)
+	}
+}
+
+func TestStaleEntries(t *testing.T) {
+	store := &MemStore{}
+	users := newUsers(t, store)
+	users.Put(bone.Int(1), user("ann", "Oslo", 34))
+	// A failed Put can leave an entry pointing at a record it no longer
+	// describes.
+	store.Put(bone.Encode([]*bone.Value{bone.List(bone.Str("users_by_city_age"), bone.Str("Oslo"), bone.Int(99), bone.Int(1))}), nil)
+	store.Put(bone.Encode([]*bone.Value{bone.List(bone.Str("users_by_city_age"), bone.Str("Oslo"), bone.Int(1), bone.Int(7))}), nil)
+	if got := lookup(t, users, "users_by_city_age", bone.Str("Oslo")); got != "[ann]" {
+		t.Errorf("Expected stale entries to be skipped, got %s", got)
+	}
+}
+
+func TestTableErrors(t *testing.T) {
+	if _, err := NewTable(&MemStore{}, "a", Index{Name: "a"}); err == nil {
+		t.Error("Expected error for index named like its table")
+	}
+	users := newUsers(t, &MemStore{})
+	if _, err := users.Lookup("missing"); err == nil {
+		t.Error("Expected error for unknown index")
+	}
+}
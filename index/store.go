@@ -0,0 +1,95 @@
+package index
+
+import (
+	"bytes"
+	"slices"
+	"sync"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+// Store is an ordered key/value store. Keys are compared as bytes, which for
+// encoded BONE keys is the order of bone.Compare.
+type Store interface {
+	Get(key []byte) ([]byte, bool, error)
+	Put(key, value []byte) error
+	Delete(key []byte) error
+	// Scan returns the entries with start <= key < end in key order. A nil
+	// end leaves the range unbounded.
+	Scan(start, end []byte) bone.Iterator
+}
+
+// MemStore is an in-memory Store, safe for concurrent use.
+type MemStore struct {
+	mu      sync.RWMutex
+	entries []memEntry
+}
+
+type memEntry struct {
+	key, value []byte
+}
+
+func compareMemEntry(e memEntry, key []byte) int {
+	return bytes.Compare(e.key, key)
+}
+
+func (s *MemStore) Get(key []byte) ([]byte, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i, found := slices.BinarySearchFunc(s.entries, key, compareMemEntry)
+	if !found {
+		return nil, false, nil
+	}
+	return s.entries[i].value, true, nil
+}
+
+func (s *MemStore) Put(key, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := memEntry{bytes.Clone(key), bytes.Clone(value)}
+	i, found := slices.BinarySearchFunc(s.entries, key, compareMemEntry)
+	if found {
+		s.entries[i] = e
+	} else {
+		s.entries = slices.Insert(s.entries, i, e)
+	}
+	return nil
+}
+
+func (s *MemStore) Delete(key []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i, found := slices.BinarySearchFunc(s.entries, key, compareMemEntry); found {
+		s.entries = slices.Delete(s.entries, i, i+1)
+	}
+	return nil
+}
+
+// Scan iterates over a snapshot of the range taken when Scan is called.
+func (s *MemStore) Scan(start, end []byte) bone.Iterator {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i, _ := slices.BinarySearchFunc(s.entries, start, compareMemEntry)
+	j := len(s.entries)
+	if end != nil {
+		j, _ = slices.BinarySearchFunc(s.entries, end, compareMemEntry)
+	}
+	if j < i {
+		j = i
+	}
+	return &memIterator{entries: slices.Clone(s.entries[i:j]), i: -1}
+}
+
+type memIterator struct {
+	entries []memEntry
+	i       int
+}
+
+func (it *memIterator) Next() bool {
+	it.i++
+	return it.i < len(it.entries)
+}
+
+func (it *memIterator) Key() []byte   { return it.entries[it.i].key }
+func (it *memIterator) Value() []byte { return it.entries[it.i].value }
+func (it *memIterator) Err() error    { return nil }
@@ -0,0 +1,40 @@
+package bone
+
+import "errors"
+
+// NewInt returns the canonical (shortest) encoding of i. 0 through 7 use the
+// B0 codes 0x10-0x17, larger values use 0x18-0x1F with 1-8 big-endian bytes
+// and negative values use 0x0F-0x08 with the low 1-8 bytes of their two's
+// complement. Encoded ints sort in numeric order.
+func NewInt(i int64) *Value {
+	b := AppendInt(nil, i)
+	v := &Value{Code: b[0]}
+	if len(b) > 1 {
+		v.Bytes = b[1:]
+	}
+	return v
+}
+
+func intWidth(i int64) int {
+	n := 1
+	if i < 0 {
+		for n < 8 && i < -(1<<(8*n)) {
+			n++
+		}
+		return n
+	}
+	for n < 8 && i >= 1<<(8*n) {
+		n++
+	}
+	return n
+}
+
+func (v *Value) Int() (int64, error) {
+	if v.Code >= 0x10 && v.Code < 0x18 {
+		return int64(v.Code - 0x10), nil
+	}
+	if (v.Code < 0x08 || v.Code >= 0x20) || !v.Complete() {
+		return 0, errors.New("not an int")
+	}
+	return intFromBytes(v.Code, v.Bytes)
+}
@@ -0,0 +1,81 @@
+package bone
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestIntRoundTrip(t *testing.T) {
+	ints := []int64{
+		math.MinInt64, math.MinInt64 + 1, -1<<56 - 1, -1 << 56, -65537, -65536, -65535,
+		-257, -256, -255, -2, -1, 0, 1, 7, 8, 255, 256, 65535, 65536,
+		1<<56 - 1, 1 << 56, math.MaxInt64 - 1, math.MaxInt64,
+	}
+	var encoded [][]byte
+	for _, i := range ints {
+		v := NewInt(i)
+		if !v.Complete() {
+			t.Fatalf("Expected complete value for %d, code 0x%02X with %d bytes", i, v.Code, len(v.Bytes))
+		}
+		values, err := Decode(Encode([]*Value{v}))
+		if err != nil {
+			t.Fatalf("Failed to decode %d: %v", i, err)
+		}
+		got, err := values[0].Int()
+		if err != nil {
+			t.Fatalf("Failed to read %d: %v", i, err)
+		}
+		if got != i {
+			t.Errorf("Expected %d, got %d", i, got)
+		}
+		encoded = append(encoded, Encode([]*Value{v}))
+	}
+	if !slices.IsSortedFunc(encoded, slices.Compare) {
+		t.Errorf("Encoded ints are not in numeric order")
+	}
+}
+
+func TestFloatOrder(t *testing.T) {
+	floats := []float64{
+		math.Inf(-1), -math.MaxFloat64, -1e10, -2, -1, -0.5, -math.SmallestNonzeroFloat64,
+		math.Copysign(0, -1), 0, math.SmallestNonzeroFloat64, 0.5, 1, 2, 1e10, math.MaxFloat64, math.Inf(1),
+	}
+	var encoded [][]byte
+	for _, f := range floats {
+		v := NewFloat(f)
+		values, err := Decode(Encode([]*Value{v}))
+		if err != nil {
+			t.Fatalf("Failed to decode %g: %v", f, err)
+		}
+		got, err := values[0].Float()
+		if err != nil || math.Float64bits(got) != math.Float64bits(f) {
+			t.Errorf("Expected %g, got %g (%v)", f, got, err)
+		}
+		if got, _, _ := ReadFloat(AppendFloat(nil, f)); math.Float64bits(got) != math.Float64bits(f) {
+			t.Errorf("Expected ReadFloat %g, got %g", f, got)
+		}
+		encoded = append(encoded, Encode([]*Value{v}))
+	}
+	if !slices.IsSortedFunc(encoded, slices.Compare) {
+		t.Errorf("Encoded floats are not in numeric order")
+	}
+	if Compare(NewFloat(-1), NewFloat(1)) != -1 || Compare(NewFloat(-2), NewFloat(-1)) != -1 {
+		t.Errorf("Expected negative floats to sort first")
+	}
+}
+
+func TestIntIllegal(t *testing.T) {
+	illegal := []*Value{
+		{Code: 0x20},
+		{Code: 0x90},
+		{Code: 0x18},
+		{Code: 0x1F, Bytes: []byte{0x80, 0, 0, 0, 0, 0, 0, 0}},
+		{Code: 0x08, Bytes: []byte{0x7F, 0, 0, 0, 0, 0, 0, 0}},
+	}
+	for _, v := range illegal {
+		if _, err := v.Int(); err == nil {
+			t.Errorf("Expected error for code 0x%02X with bytes %X", v.Code, v.Bytes)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Package benchdata generates reproducible BONE inputs of various shapes for
+// benchmarks. Every generator is seeded, so a given size always produces the
+// same values.
+package benchdata
+
+import (
+	"math/rand/v2"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+type Shape struct {
+	Name     string
+	Generate func(n int) []*bone.Value
+}
+
+var Shapes = []Shape{
+	{"flat-ints", FlatInts},
+	{"escaped-strings", EscapedStrings},
+	{"nested-lists", NestedLists},
+	{"wide-tuples", WideTuples},
+}
+
+func newRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+// FlatInts returns n top level ints spread across every int width.
+func FlatInts(n int) []*bone.Value {
+	r := newRand(1)
+	values := make([]*bone.Value, n)
+	for i := range values {
+		values[i] = bone.NewInt(r.Int64() >> r.IntN(64))
+	}
+	return values
+}
+
+// EscapedStrings returns n byte strings of 1 KiB where roughly one byte in
+// four is 0x00 and so has to be escaped.
+func EscapedStrings(n int) []*bone.Value {
+	r := newRand(2)
+	values := make([]*bone.Value, n)
+	for i := range values {
+		b := make([]byte, 1024)
+		for j := range b {
+			if r.IntN(4) != 0 {
+				b[j] = byte(r.IntN(255) + 1)
+			}
+		}
+		values[i] = bone.NewBytes(b)
+	}
+	return values
+}
+
+// NestedLists returns n lists nested 64 deep, each level holding a small int
+// and a string alongside the next list.
+func NestedLists(n int) []*bone.Value {
+	r := newRand(3)
+	values := make([]*bone.Value, n)
+	for i := range values {
+		v := bone.List()
+		for range 64 {
+			v = bone.List(bone.Int(r.Int64N(1000)), v, bone.Str("level"))
+		}
+		values[i] = v
+	}
+	return values
+}
+
+// WideTuples returns n T5 tuples of mixed primitives, the shape of a typical
+// composite key.
+func WideTuples(n int) []*bone.Value {
+	r := newRand(4)
+	values := make([]*bone.Value, n)
+	for i := range values {
+		values[i] = bone.Tuple(
+			bone.Str("tenant-"+string(rune('a'+r.IntN(26)))),
+			bone.Int(r.Int64()),
+			bone.Float(r.Float64()),
+			bone.Bool(r.IntN(2) == 0),
+			bone.Tuple(bone.Int(r.Int64N(1<<20)), bone.Raw([]byte{byte(r.Uint32())})),
+		)
+	}
+	return values
+}
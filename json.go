@@ -0,0 +1,200 @@
+package bone
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// FromJSON reads a single JSON value from r and converts it to BONE:
+//
+//	null, false, true   B0 codes 0x22, 0x20, 0x21
+//	integer literals    ints (NewInt), or big ints when outside int64
+//	other numbers       floats (NewFloat, code 0x70)
+//	strings             UTF-8 text (NewText, code 0x91)
+//	arrays              lists (code 0xF0)
+//	objects             maps (code 0xF1), lists of T2 (code 0xB0) key/value
+//	                    pairs sorted by encoded key
+//
+// Numbers sort numerically only within each encoding: ints sort before floats,
+// and big ints after both, whatever their values.
+func FromJSON(r io.Reader) (*Value, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var x any
+	if err := dec.Decode(&x); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("trailing data after json value")
+	}
+	return fromJSON(x)
+}
+
+func fromJSON(x any) (*Value, error) {
+	switch x := x.(type) {
+	case nil:
+		return &Value{Code: CodeNull}, nil
+	case bool:
+		if x {
+			return &Value{Code: CodeTrue}, nil
+		}
+		return &Value{Code: CodeFalse}, nil
+	case json.Number:
+		s := x.String()
+		if !strings.ContainsAny(s, ".eE") {
+			if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return NewInt(i), nil
+			}
+			b, _ := new(big.Int).SetString(s, 10)
+			return NewBigInt(b), nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return NewFloat(f), nil
+	case string:
+		return NewText(x), nil
+	case []any:
+		list := &Value{Code: CodeList, Values: []*Value{}}
+		for _, e := range x {
+			v, err := fromJSON(e)
+			if err != nil {
+				return nil, err
+			}
+			list.Values = append(list.Values, v)
+		}
+		return list, nil
+	case map[string]any:
+		pairs := make([][2]*Value, 0, len(x))
+		for k, e := range x {
+			v, err := fromJSON(e)
+			if err != nil {
+				return nil, err
+			}
+			pairs = append(pairs, [2]*Value{NewText(k), v})
+		}
+		return NewMap(pairs)
+	}
+	return nil, errors.New("unsupported json value")
+}
+
+// ToJSON writes v to w as compact JSON, reversing FromJSON. Ints, big ints and
+// decimals become numbers, and floats numbers with a fraction or exponent so
+// they read back as floats. Text and collated strings become JSON strings,
+// other strings become base64, maps become objects, and remaining lists and
+// tuples become arrays. Values without a JSON mapping are an error.
+func ToJSON(v *Value, w io.Writer) error {
+	b, err := appendJSON(nil, v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func appendJSON(b []byte, v *Value) ([]byte, error) {
+	if v.Level != 0 {
+		return nil, errors.New("no json mapping for level extended value")
+	}
+	switch {
+	case v.Code == CodeNull:
+		return append(b, "null"...), nil
+	case v.Code == CodeFalse:
+		return append(b, "false"...), nil
+	case v.Code == CodeTrue:
+		return append(b, "true"...), nil
+	case v.Code < 0x20 || v.Code == CodeBigInt:
+		x, err := v.BigInt()
+		if err != nil {
+			return nil, err
+		}
+		return x.Append(b, 10), nil
+	case v.Code == CodeDecimal:
+		mant, exp, err := v.Decimal()
+		if err != nil {
+			return nil, err
+		}
+		b = mant.Append(b, 10)
+		if exp != 0 {
+			b = append(b, 'e')
+			b = strconv.AppendInt(b, int64(exp), 10)
+		}
+		return b, nil
+	case v.Code == CodeFloat:
+		f, err := v.Float()
+		if err != nil {
+			return nil, err
+		}
+		s, err := json.Marshal(f)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, s...)
+		// Keep integral floats from reading back as ints.
+		if !bytes.ContainsAny(s, ".eE") {
+			b = append(b, ".0"...)
+		}
+		return b, nil
+	case v.Code == CodeText:
+		s, err := v.Text()
+		if err != nil {
+			return nil, err
+		}
+		return appendJSONString(b, s), nil
+	case v.Code == CodeCollated:
+		s, err := v.Collated()
+		if err != nil {
+			return nil, err
+		}
+		return appendJSONString(b, s), nil
+	case v.String():
+		return appendJSONString(b, base64.StdEncoding.EncodeToString(v.Bytes)), nil
+	case v.Code == CodeMap:
+		b = append(b, '{')
+		for i, pair := range v.Values {
+			if pair.Code != CodePair || len(pair.Values) != 2 || pair.Values[0].Code != CodeText {
+				return nil, errors.New("no json mapping for map entry")
+			}
+			if i > 0 {
+				b = append(b, ',')
+			}
+			var err error
+			if b, err = appendJSON(b, pair.Values[0]); err != nil {
+				return nil, err
+			}
+			b = append(b, ':')
+			if b, err = appendJSON(b, pair.Values[1]); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, '}'), nil
+	case v.Code >= 0xA0:
+		b = append(b, '[')
+		for i, e := range v.Values {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			var err error
+			if b, err = appendJSON(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, ']'), nil
+	}
+	return nil, errors.New("no json mapping for value")
+}
+
+func appendJSONString(b []byte, s string) []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return append(b, bytes.TrimSuffix(buf.Bytes(), []byte("\n"))...)
+}
@@ -0,0 +1,108 @@
+package bone
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	cases := []struct {
+		in  string
+		out string
+	}{
+		{`null`, `null`},
+		{`true`, `true`},
+		{`false`, `false`},
+		{`0`, `0`},
+		{`-86`, `-86`},
+		{`9223372036854775807`, `9223372036854775807`},
+		{`-123456789012345678901234567890`, `-123456789012345678901234567890`},
+		{`2.5`, `2.5`},
+		{`1.0`, `1.0`},
+		{`-0.0`, `-0.0`},
+		{`1E2`, `100.0`},
+		{`1e21`, `1e+21`},
+		{`-1e300`, `-1e+300`},
+		{`"héllo\u0000<>"`, `"héllo\u0000<>"`},
+		{`[]`, `[]`},
+		{`{}`, `{}`},
+		{` [1, [2, [3]], {"x": null}] `, `[1,[2,[3]],{"x":null}]`},
+		{`{"b": 1, "a": 2, "ab": 3, "": 4}`, `{"":4,"a":2,"ab":3,"b":1}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			v, err := FromJSON(strings.NewReader(tc.in))
+			if err != nil {
+				t.Fatalf("Failed to convert json: %v", err)
+			}
+			values, err := DecodeStrict(Encode([]*Value{v}))
+			if err != nil {
+				t.Fatalf("Failed to decode payload: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := ToJSON(values[0], &buf); err != nil {
+				t.Fatalf("Failed to convert to json: %v", err)
+			}
+			if buf.String() != tc.out {
+				t.Errorf("Expected %s, got %s", tc.out, buf.String())
+			}
+		})
+	}
+}
+
+func TestJSONIntegerOrder(t *testing.T) {
+	ints := []string{"-9223372036854775808", "-256", "-1", "0", "7", "300", "9223372036854775807"}
+	var encoded [][]byte
+	for _, s := range ints {
+		v, err := FromJSON(strings.NewReader(s))
+		if err != nil {
+			t.Fatalf("Failed to convert %s: %v", s, err)
+		}
+		encoded = append(encoded, Encode([]*Value{v}))
+	}
+	if !slices.IsSortedFunc(encoded, bytes.Compare) {
+		t.Errorf("JSON integers are not in numeric order")
+	}
+}
+
+func TestJSONMapping(t *testing.T) {
+	v, err := FromJSON(strings.NewReader(`{"k": [null, false, true, 7, 1.5, "s"]}`))
+	if err != nil {
+		t.Fatalf("Failed to convert json: %v", err)
+	}
+	expected := []byte{
+		0xF1,
+		0xB0, 0x91, 0x6B, 0x00,
+		0xF0, 0x22, 0x20, 0x21, 0x17,
+		0x70, 0xBF, 0xF8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x91, 0x73, 0x00,
+		0x00,
+		0x00,
+	}
+	if got := Encode([]*Value{v}); !bytes.Equal(got, expected) {
+		t.Errorf("Expected % X, got % X", expected, got)
+	}
+
+	var buf bytes.Buffer
+	if err := ToJSON(NewBytes([]byte{0x00, 0xFF}), &buf); err != nil || buf.String() != `"AP8="` {
+		t.Errorf("Expected raw bytes as base64, got %s, %v", buf.String(), err)
+	}
+
+	for _, v := range []*Value{
+		{Code: 0x30, Bytes: []byte{0x01}},
+		{Code: CodeTrue, Level: 1},
+		{Code: CodeMap, Values: []*Value{{Code: CodePair, Values: []*Value{NewInt(1), NewInt(2)}}}},
+		{Code: CodeFloat, Bytes: []byte{0xFF, 0xF8, 0, 0, 0, 0, 0, 1}},
+	} {
+		if err := ToJSON(v, &bytes.Buffer{}); err == nil {
+			t.Errorf("Expected error converting code 0x%02X to json", v.Code)
+		}
+	}
+	for _, in := range []string{``, `[1,`, `1 2`} {
+		if _, err := FromJSON(strings.NewReader(in)); err == nil {
+			t.Errorf("Expected error converting %q", in)
+		}
+	}
+}
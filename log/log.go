@@ -0,0 +1,372 @@
+// Package log implements an append-only log of BONE values stored as framed
+// records in a directory of segment files.
+//
+// Every record gets an offset, its sequence number in the log, and is stored
+// as a frame (see package frame) holding the T2 tuple (offset, value).
+// Segments are named after the offset of their first record and a new one is
+// started once the current segment reaches MaxSegmentSize. Because offsets
+// are stored rather than derived from positions, they survive Compact.
+//
+// Open recovers from a crash during Append by truncating a torn record at the
+// end of the last segment.
+package log
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mrmcc3/bone-go"
+	"github.com/mrmcc3/bone-go/frame"
+)
+
+// DefaultMaxSegmentSize is the segment size at which Open configures a log to
+// rotate.
+const DefaultMaxSegmentSize = 64 << 20
+
+const segmentSuffix = ".log"
+
+type Log struct {
+	// MaxSegmentSize is the size in bytes after which Append starts a new
+	// segment.
+	MaxSegmentSize int64
+
+	mu       sync.Mutex
+	dir      string
+	segments []int64
+	active   *os.File
+	w        *frame.Writer
+	size     int64
+	next     int64
+	err      error
+}
+
+func segmentName(base int64) string {
+	return fmt.Sprintf("%016x%s", base, segmentSuffix)
+}
+
+// Open opens the log in dir, creating the directory if needed.
+func Open(dir string) (*Log, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	l := &Log{MaxSegmentSize: DefaultMaxSegmentSize, dir: dir}
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), segmentSuffix)
+		if !ok || e.IsDir() {
+			continue
+		}
+		base, err := strconv.ParseInt(name, 16, 64)
+		if err != nil {
+			continue
+		}
+		l.segments = append(l.segments, base)
+	}
+	slices.Sort(l.segments)
+	if len(l.segments) == 0 {
+		l.segments = []int64{0}
+	}
+	if err := l.openActive(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// openActive opens the last segment for appending, truncating a torn record
+// at its end.
+func (l *Log) openActive() error {
+	base := l.segments[len(l.segments)-1]
+	f, err := os.OpenFile(filepath.Join(l.dir, segmentName(base)), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	l.next = base
+	r := frame.NewReader(f)
+	for {
+		v, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if errors.Is(err, frame.ErrTorn) {
+			if err := f.Truncate(r.Offset()); err != nil {
+				f.Close()
+				return err
+			}
+			break
+		}
+		if err != nil {
+			f.Close()
+			return err
+		}
+		offset, _, err := record(v)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		l.next = offset + 1
+	}
+	if _, err := f.Seek(r.Offset(), io.SeekStart); err != nil {
+		f.Close()
+		return err
+	}
+	l.active, l.w, l.size = f, frame.NewWriter(f), r.Offset()
+	return nil
+}
+
+func record(v *bone.Value) (int64, *bone.Value, error) {
+	if v.Code != bone.CodePair || v.Level != 0 {
+		return 0, nil, errors.New("log: invalid record")
+	}
+	offset, err := v.Values[0].Int()
+	if err != nil {
+		return 0, nil, errors.New("log: invalid record offset")
+	}
+	return offset, v.Values[1], nil
+}
+
+// Append adds v to the log and returns its offset.
+func (l *Log) Append(v *bone.Value) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active == nil {
+		return 0, errors.New("log: closed")
+	}
+	if l.err != nil {
+		return 0, l.err
+	}
+	rec := bone.Tuple(bone.Int(l.next), v)
+	// A record the frame reader would reject could never be read back.
+	if bone.EncodedLen(rec) > frame.DefaultMaxSize {
+		return 0, frame.ErrTooLarge
+	}
+	if l.size >= l.MaxSegmentSize && l.next != l.segments[len(l.segments)-1] {
+		if err := l.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	if err := l.w.Write(rec); err != nil {
+		// Cut off any partial frame so later records do not follow it;
+		// if that fails too the segment can no longer be appended to.
+		if terr := l.active.Truncate(l.size); terr != nil {
+			l.err = terr
+		} else if _, serr := l.active.Seek(l.size, io.SeekStart); serr != nil {
+			l.err = serr
+		}
+		return 0, err
+	}
+	l.size += int64(frame.HeaderSize + bone.EncodedLen(rec) + frame.TrailerSize)
+	l.next++
+	return l.next - 1, nil
+}
+
+// rotate seals the active segment and starts a new one at the next offset.
+func (l *Log) rotate() error {
+	if err := l.active.Sync(); err != nil {
+		return err
+	}
+	if err := l.active.Close(); err != nil {
+		return err
+	}
+	l.active = nil
+	l.segments = append(l.segments, l.next)
+	return l.openActive()
+}
+
+// Sync commits the active segment to stable storage.
+func (l *Log) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active == nil {
+		return errors.New("log: closed")
+	}
+	return l.active.Sync()
+}
+
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active == nil {
+		return errors.New("log: closed")
+	}
+	err := l.active.Sync()
+	if cerr := l.active.Close(); err == nil {
+		err = cerr
+	}
+	l.active = nil
+	return err
+}
+
+// Iterate returns an iterator over the records with offsets from from
+// onwards. Records appended to the active segment while iterating are
+// included, but the segments the iterator visits are fixed when Iterate is
+// called, so records in segments started by a later rotation are not.
+func (l *Log) Iterate(from int64) *Iterator {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	i, found := slices.BinarySearch(l.segments, from)
+	if !found && i > 0 {
+		i--
+	}
+	return &Iterator{dir: l.dir, segments: l.segments[i:len(l.segments):len(l.segments)], from: from}
+}
+
+// Iterator steps through the records of a log. It must be closed after use.
+type Iterator struct {
+	dir      string
+	segments []int64
+	from     int64
+	f        *os.File
+	r        *frame.Reader
+	offset   int64
+	value    *bone.Value
+	err      error
+}
+
+// Next advances to the next record, returning false at the end of the log or
+// on error.
+func (it *Iterator) Next() bool {
+	for it.err == nil {
+		if it.r == nil {
+			if len(it.segments) == 0 {
+				return false
+			}
+			f, err := os.Open(filepath.Join(it.dir, segmentName(it.segments[0])))
+			if errors.Is(err, os.ErrNotExist) {
+				// Removed by Compact since Iterate was called.
+				it.segments = it.segments[1:]
+				continue
+			}
+			if err != nil {
+				it.err = err
+				return false
+			}
+			it.f, it.r = f, frame.NewReader(f)
+		}
+		v, err := it.r.Next()
+		if err == io.EOF || (errors.Is(err, frame.ErrTorn) && len(it.segments) == 1) {
+			// The end of the last segment may hold a record still being
+			// written.
+			it.f.Close()
+			it.f, it.r = nil, nil
+			it.segments = it.segments[1:]
+			continue
+		}
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if it.offset, it.value, it.err = record(v); it.err != nil {
+			return false
+		}
+		if it.offset >= it.from {
+			return true
+		}
+	}
+	return false
+}
+
+// Offset returns the offset of the current record.
+func (it *Iterator) Offset() int64 {
+	return it.offset
+}
+
+func (it *Iterator) Value() *bone.Value {
+	return it.value
+}
+
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+func (it *Iterator) Close() error {
+	it.segments = nil
+	if it.f == nil {
+		return nil
+	}
+	err := it.f.Close()
+	it.f, it.r = nil, nil
+	return err
+}
+
+// Compact rewrites the sealed segments, keeping only the latest record for
+// each key, where key maps a value to its key. Records for which key returns
+// nil are always kept. The active segment is not rewritten but its records
+// count when deciding which records are the latest.
+func (l *Log) Compact(key func(*bone.Value) *bone.Value) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active == nil {
+		return errors.New("log: closed")
+	}
+	latest := map[string]int64{}
+	it := &Iterator{dir: l.dir, segments: l.segments}
+	for it.Next() {
+		if k := key(it.value); k != nil {
+			latest[string(bone.Encode([]*bone.Value{k}))] = it.offset
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	it.Close()
+
+	// Build a new slice: iterators may share the old one.
+	var kept []int64
+	for _, base := range l.segments[:len(l.segments)-1] {
+		n, err := l.compactSegment(base, key, latest)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			kept = append(kept, base)
+		}
+	}
+	l.segments = append(kept, l.segments[len(l.segments)-1])
+	return nil
+}
+
+// compactSegment rewrites the segment starting at base and returns how many
+// records it kept. An empty result removes the segment.
+func (l *Log) compactSegment(base int64, key func(*bone.Value) *bone.Value, latest map[string]int64) (int, error) {
+	path := filepath.Join(l.dir, segmentName(base))
+	tmp, err := os.CreateTemp(l.dir, segmentName(base)+".compact-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	w := frame.NewWriter(tmp)
+	it := &Iterator{dir: l.dir, segments: []int64{base}}
+	defer it.Close()
+	n := 0
+	for it.Next() {
+		if k := key(it.value); k != nil && latest[string(bone.Encode([]*bone.Value{k}))] != it.offset {
+			continue
+		}
+		if err := w.Write(bone.Tuple(bone.Int(it.offset), it.value)); err != nil {
+			return 0, err
+		}
+		n++
+	}
+	if err := it.Err(); err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, os.Remove(path)
+	}
+	if err := tmp.Sync(); err != nil {
+		return 0, err
+	}
+	return n, os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,206 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mrmcc3/bone-go"
+	"github.com/mrmcc3/bone-go/frame"
+)
+
+func entry(k string, v int64) *bone.Value {
+	return bone.Tuple(bone.Str(k), bone.Int(v))
+}
+
+func openLog(t *testing.T, dir string) *Log {
+	l, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Failed to open log: %v", err)
+	}
+	l.MaxSegmentSize = 64
+	return l
+}
+
+func appendAll(t *testing.T, l *Log, values ...*bone.Value) {
+	for _, v := range values {
+		if _, err := l.Append(v); err != nil {
+			t.Fatalf("Failed to append: %v", err)
+		}
+	}
+}
+
+func readAll(t *testing.T, l *Log, from int64) ([]int64, []*bone.Value) {
+	it := l.Iterate(from)
+	defer it.Close()
+	var offsets []int64
+	var values []*bone.Value
+	for it.Next() {
+		offsets = append(offsets, it.Offset())
+		values = append(values, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Unexpected iteration error: %v", err)
+	}
+	return offsets, values
+}
+
+func segmentCount(t *testing.T, dir string) int {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*"+segmentSuffix))
+	return len(matches)
+}
+
+func TestAppendIterate(t *testing.T) {
+	dir := t.TempDir()
+	l := openLog(t, dir)
+	for i := range 20 {
+		offset, err := l.Append(entry("k", int64(i)))
+		if err != nil || offset != int64(i) {
+			t.Fatalf("Expected offset %d, got %d %v", i, offset, err)
+		}
+	}
+	if n := segmentCount(t, dir); n < 3 {
+		t.Errorf("Expected rotation into several segments, got %d", n)
+	}
+	for _, from := range []int64{0, 7, 19, 20} {
+		offsets, values := readAll(t, l, from)
+		if len(offsets) != 20-int(from) {
+			t.Fatalf("From %d: expected %d records, got %d", from, 20-from, len(offsets))
+		}
+		for i, v := range values {
+			if offsets[i] != from+int64(i) || bone.Compare(v, entry("k", from+int64(i))) != 0 {
+				t.Fatalf("From %d: record %d does not match", from, i)
+			}
+		}
+	}
+	l.Close()
+
+	l = openLog(t, dir)
+	defer l.Close()
+	if offset, _ := l.Append(entry("k", 20)); offset != 20 {
+		t.Errorf("Expected offset 20 after reopen, got %d", offset)
+	}
+}
+
+func TestTornTail(t *testing.T) {
+	dir := t.TempDir()
+	l := openLog(t, dir)
+	l.MaxSegmentSize = DefaultMaxSegmentSize
+	appendAll(t, l, entry("a", 1), entry("b", 2))
+	l.Close()
+
+	path := filepath.Join(dir, segmentName(0))
+	info, _ := os.Stat(path)
+	if err := os.Truncate(path, info.Size()-3); err != nil {
+		t.Fatal(err)
+	}
+	l = openLog(t, dir)
+	defer l.Close()
+	if offset, _ := l.Append(entry("c", 3)); offset != 1 {
+		t.Errorf("Expected torn record's offset 1 to be reused, got %d", offset)
+	}
+	_, values := readAll(t, l, 0)
+	if len(values) != 2 || bone.Compare(values[1], entry("c", 3)) != 0 {
+		t.Errorf("Expected records a and c, got %d records", len(values))
+	}
+}
+
+func TestCompact(t *testing.T) {
+	dir := t.TempDir()
+	l := openLog(t, dir)
+	defer l.Close()
+	for i := range 30 {
+		appendAll(t, l, entry(string(rune('a'+i%3)), int64(i)))
+	}
+	appendAll(t, l, bone.Null)
+	before := segmentCount(t, dir)
+	key := func(v *bone.Value) *bone.Value {
+		if v.Code != bone.CodePair {
+			return nil
+		}
+		return v.Values[0]
+	}
+	if err := l.Compact(key); err != nil {
+		t.Fatalf("Failed to compact: %v", err)
+	}
+	if after := segmentCount(t, dir); after >= before {
+		t.Errorf("Expected fewer segments after compaction, got %d from %d", after, before)
+	}
+	offsets, values := readAll(t, l, 0)
+	for i, offset := range offsets {
+		if values[i].Code == bone.CodePair {
+			if v, _ := values[i].Values[1].Int(); v != offset {
+				t.Errorf("Record at offset %d holds %d", offset, v)
+			}
+		}
+	}
+	seen := map[string]int{}
+	for _, v := range values {
+		if k := key(v); k != nil {
+			seen[string(k.Bytes)]++
+		}
+	}
+	if seen["a"] != 1 || seen["b"] != 1 || seen["c"] != 1 {
+		t.Errorf("Expected one record per key in sealed segments, got %v", seen)
+	}
+	if offset, _ := l.Append(entry("a", 31)); offset != 31 {
+		t.Errorf("Expected offset 31 after compaction, got %d", offset)
+	}
+}
+
+// shortWriter writes the first n bytes it is given and then fails, like a
+// write cut short by a full disk.
+type shortWriter struct {
+	f *os.File
+	n int
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	n, _ := w.f.Write(p[:min(w.n, len(p))])
+	return n, errors.New("no space left on device")
+}
+
+func TestAppendWriteError(t *testing.T) {
+	dir := t.TempDir()
+	l := openLog(t, dir)
+	l.MaxSegmentSize = DefaultMaxSegmentSize
+	appendAll(t, l, entry("a", 1))
+	w := l.w
+	l.w = frame.NewWriter(&shortWriter{f: l.active, n: 5})
+	if _, err := l.Append(entry("b", 2)); err == nil {
+		t.Fatal("Expected write error")
+	}
+	l.w = w
+	if offset, err := l.Append(entry("c", 3)); err != nil || offset != 1 {
+		t.Fatalf("Expected offset 1 after failed write, got %d %v", offset, err)
+	}
+	l.Close()
+
+	l = openLog(t, dir)
+	defer l.Close()
+	_, values := readAll(t, l, 0)
+	if len(values) != 2 || bone.Compare(values[1], entry("c", 3)) != 0 {
+		t.Errorf("Expected records a and c after reopen, got %d records", len(values))
+	}
+}
+
+func TestAppendTooLarge(t *testing.T) {
+	dir := t.TempDir()
+	l := openLog(t, dir)
+	appendAll(t, l, entry("a", 1))
+	big := bone.Raw(bytes.Repeat([]byte{'x'}, frame.DefaultMaxSize))
+	if _, err := l.Append(big); !errors.Is(err, frame.ErrTooLarge) {
+		t.Fatalf("Expected ErrTooLarge, got %v", err)
+	}
+	appendAll(t, l, entry("b", 2))
+	l.Close()
+
+	l = openLog(t, dir)
+	defer l.Close()
+	offsets, _ := readAll(t, l, 0)
+	if len(offsets) != 2 || offsets[1] != 1 {
+		t.Errorf("Expected offsets 0 and 1 after reopen, got %v", offsets)
+	}
+}
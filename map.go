@@ -0,0 +1,89 @@
+package bone
+
+import (
+	"errors"
+	"slices"
+)
+
+// NewMap returns a map (code 0xF1): a list of T2 (code 0xB0) key/value pairs
+// sorted by encoded key. Duplicate keys are an error, so a given map always
+// encodes identically.
+func NewMap(pairs [][2]*Value) (*Value, error) {
+	m := &Value{Code: CodeMap, Values: make([]*Value, 0, len(pairs))}
+	for _, p := range pairs {
+		m.Values = append(m.Values, &Value{Code: CodePair, Values: []*Value{p[0], p[1]}})
+	}
+	slices.SortFunc(m.Values, comparePairs)
+	if err := checkSorted(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NewSet returns a set (code 0xF2): a list of items sorted by encoded bytes.
+// Duplicate items are an error.
+func NewSet(items []*Value) (*Value, error) {
+	s := &Value{Code: CodeSet, Values: slices.Clone(items)}
+	if s.Values == nil {
+		s.Values = []*Value{}
+	}
+	slices.SortFunc(s.Values, Compare)
+	if err := checkSorted(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Lookup finds key in a map or set by binary search. For maps it returns the
+// associated value, for sets the matching item.
+func (v *Value) Lookup(key *Value) (*Value, bool) {
+	switch v.Code {
+	case CodeMap:
+		// A map from a lenient decode may hold entries that are not pairs;
+		// meeting one means the map is malformed and the key is not found.
+		malformed := false
+		i, ok := slices.BinarySearchFunc(v.Values, key, func(p, k *Value) int {
+			if p.Code != CodePair || len(p.Values) != 2 {
+				malformed = true
+				return -1
+			}
+			return Compare(p.Values[0], k)
+		})
+		if !ok || malformed {
+			return nil, false
+		}
+		return v.Values[i].Values[1], true
+	case CodeSet:
+		i, ok := slices.BinarySearchFunc(v.Values, key, Compare)
+		if !ok {
+			return nil, false
+		}
+		return v.Values[i], true
+	}
+	return nil, false
+}
+
+func comparePairs(a, b *Value) int {
+	return Compare(a.Values[0], b.Values[0])
+}
+
+func checkSorted(v *Value) error {
+	switch v.Code {
+	case CodeMap:
+		for i, p := range v.Values {
+			if p.Code != CodePair || len(p.Values) != 2 {
+				return errors.New("map entry is not a pair")
+			}
+			if i > 0 && comparePairs(v.Values[i-1], p) >= 0 {
+				return errors.New("map keys not sorted or duplicated")
+			}
+		}
+	case CodeSet:
+		for i := 1; i < len(v.Values); i++ {
+			if Compare(v.Values[i-1], v.Values[i]) >= 0 {
+				return errors.New("set items not sorted or duplicated")
+			}
+		}
+	}
+	return nil
+}
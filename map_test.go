@@ -0,0 +1,114 @@
+package bone
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	m, err := NewMap([][2]*Value{
+		{NewText("b"), NewInt(2)},
+		{NewInt(300), NewText("int key")},
+		{NewText("a"), NewInt(1)},
+		{NewText("ab"), NewInt(3)},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build map: %v", err)
+	}
+	reordered, _ := NewMap([][2]*Value{
+		{NewText("ab"), NewInt(3)},
+		{NewText("a"), NewInt(1)},
+		{NewInt(300), NewText("int key")},
+		{NewText("b"), NewInt(2)},
+	})
+	payload := Encode([]*Value{m})
+	if !bytes.Equal(payload, Encode([]*Value{reordered})) {
+		t.Errorf("Expected insertion order not to affect encoding")
+	}
+	values, err := DecodeStrict(payload)
+	if err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	for _, tc := range []struct {
+		key   *Value
+		value int64
+	}{
+		{NewText("a"), 1},
+		{NewText("ab"), 3},
+		{NewText("b"), 2},
+	} {
+		v, ok := values[0].Lookup(tc.key)
+		if !ok {
+			t.Fatalf("Missing key %q", tc.key.Bytes)
+		}
+		if i, _ := v.Int(); i != tc.value {
+			t.Errorf("Key %q: expected %d, got %d", tc.key.Bytes, tc.value, i)
+		}
+	}
+	if v, ok := values[0].Lookup(NewInt(300)); !ok || string(v.Bytes) != "int key" {
+		t.Errorf("Expected int key lookup to succeed")
+	}
+	if _, ok := values[0].Lookup(NewText("c")); ok {
+		t.Errorf("Expected missing key lookup to fail")
+	}
+	for _, data := range [][]byte{
+		{0xF1, 0x20, 0x00},
+		{0xF1, 0xA0, 0x11, 0xB0, 0x12, 0x13, 0x00},
+		{0xF1, 0xB0, 0x10, 0x10, 0xF0, 0x11, 0x00, 0x00},
+	} {
+		lenient, err := Decode(data)
+		if err != nil {
+			t.Fatalf("Failed to decode % X: %v", data, err)
+		}
+		if _, ok := lenient[0].Lookup(NewInt(1)); ok {
+			t.Errorf("Expected lookup in malformed map % X to fail", data)
+		}
+	}
+	if _, err := NewMap([][2]*Value{{NewText("a"), NewInt(1)}, {NewText("a"), NewInt(2)}}); err == nil {
+		t.Errorf("Expected error for duplicate map keys")
+	}
+}
+
+func TestSet(t *testing.T) {
+	s, err := NewSet([]*Value{NewInt(3), NewInt(-1), NewText("x"), NewInt(0)})
+	if err != nil {
+		t.Fatalf("Failed to build set: %v", err)
+	}
+	expected := []byte{0xF2, 0x0F, 0xFF, 0x10, 0x13, 0x91, 0x78, 0x00, 0x00}
+	if got := Encode([]*Value{s}); !bytes.Equal(got, expected) {
+		t.Errorf("Expected % X, got % X", expected, got)
+	}
+	if _, ok := s.Lookup(NewInt(-1)); !ok {
+		t.Errorf("Expected -1 to be in set")
+	}
+	if _, ok := s.Lookup(NewInt(1)); ok {
+		t.Errorf("Expected 1 not to be in set")
+	}
+	if _, err := NewSet([]*Value{NewInt(1), NewInt(1)}); err == nil {
+		t.Errorf("Expected error for duplicate set items")
+	}
+	if empty, err := NewSet(nil); err != nil || !bytes.Equal(Encode([]*Value{empty}), []byte{0xF2, 0x00}) {
+		t.Errorf("Expected empty set to encode as F2 00, got %v", err)
+	}
+}
+
+func TestDecodeStrictMapSet(t *testing.T) {
+	illegal := [][]byte{
+		{0xF1, 0xB0, 0x11, 0x20, 0xB0, 0x10, 0x21, 0x00},
+		{0xF1, 0xB0, 0x11, 0x20, 0xB0, 0x11, 0x21, 0x00},
+		{0xF1, 0xA0, 0x11, 0x00},
+		{0xF2, 0x12, 0x11, 0x00},
+		{0xF0, 0xF2, 0x11, 0x11, 0x00, 0x00},
+	}
+	for _, payload := range illegal {
+		if _, err := Decode(payload); err != nil {
+			t.Errorf("Unexpected error for % X in lenient mode: %v", payload, err)
+		}
+		if _, err := DecodeStrict(payload); err == nil {
+			t.Errorf("Expected error for unsorted % X", payload)
+		}
+	}
+	if _, err := DecodeStrict([]byte{0xFF, 0xF2, 0x12, 0x11, 0x00}); err != nil {
+		t.Errorf("Unexpected error for level extended list: %v", err)
+	}
+}
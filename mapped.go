@@ -0,0 +1,161 @@
+package bone
+
+import (
+	"bytes"
+	"errors"
+)
+
+// Mapped is a read-only file of BONE values mapped into memory. Values are
+// reached through Views, which decode lazily and refer to the mapping rather
+// than copying it, so a file far larger than the heap can be queried.
+type Mapped struct {
+	data  []byte
+	unmap func() error
+}
+
+// OpenMapped maps the file at path. On Linux the file is mapped with mmap;
+// elsewhere it is read into memory. Views and values obtained from it must not
+// be used after Close.
+func OpenMapped(path string) (*Mapped, error) {
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Mapped{data: data, unmap: unmap}, nil
+}
+
+// Data returns the mapped bytes.
+func (m *Mapped) Data() []byte {
+	return m.data
+}
+
+func (m *Mapped) Close() error {
+	if m.unmap == nil {
+		return errors.New("mapped file already closed")
+	}
+	err := m.unmap()
+	m.data, m.unmap = nil, nil
+	return err
+}
+
+// At returns the view of the top level value starting at offset off. The next
+// value starts at off + v.Len().
+func (m *Mapped) At(off int) (View, error) {
+	if off < 0 || off >= len(m.data) {
+		return View{}, errors.New("offset out of range")
+	}
+	return viewAt(m.data[off:])
+}
+
+func viewAt(data []byte) (View, error) {
+	n, err := Skip(data)
+	if err != nil {
+		return View{}, err
+	}
+	// Cap the view so appending to anything taken from it copies rather
+	// than writing into the mapping.
+	return View{data: data[:n:n]}, nil
+}
+
+// View is the encoding of a single value, checked with Skip but otherwise
+// undecoded.
+type View struct {
+	data []byte
+}
+
+// Encoded returns the encoding of the value.
+func (v View) Encoded() []byte {
+	return v.data
+}
+
+// Len returns the length of the encoding.
+func (v View) Len() int {
+	return len(v.data)
+}
+
+// header returns the number of level extensions and so the index of the
+// type code.
+func (v View) header() int {
+	i := 0
+	for v.data[i] == 0xFF {
+		i++
+	}
+	return i
+}
+
+func (v View) Code() byte {
+	return v.data[v.header()]
+}
+
+func (v View) Level() int {
+	return v.header()
+}
+
+// Bytes returns the bytes of a block or string. Block bytes and strings
+// without escaped null bytes refer to the view; other strings are copied.
+func (v View) Bytes() []byte {
+	h := v.header() + 1
+	switch codeTable[v.data[h-1]].kind {
+	case kindBlock:
+		if h == len(v.data) {
+			return nil
+		}
+		return v.data[h:]
+	case kindString:
+		s := v.data[h : len(v.data)-1]
+		if bytes.IndexByte(s, 0x00) < 0 {
+			return s[:len(s):len(s)]
+		}
+		return bytes.ReplaceAll(s, []byte{0x00, 0x01}, []byte{0x00})
+	}
+	return nil
+}
+
+// Children returns the views of the values in a tuple or list.
+func (v View) Children() []View {
+	if k := codeTable[v.Code()].kind; k != kindTuple && k != kindList {
+		return nil
+	}
+	var children []View
+	for i := v.header() + 1; i < len(v.data); {
+		if v.data[i] == 0x00 {
+			break
+		}
+		// The encoding was checked by Skip, so children cannot fail.
+		c, _ := viewAt(v.data[i:])
+		children = append(children, c)
+		i += c.Len()
+	}
+	return children
+}
+
+// Child returns the i-th value in a tuple or list, skipping over the ones
+// before it without decoding them.
+func (v View) Child(i int) (View, bool) {
+	j := v.header() + 1
+	switch codeTable[v.data[j-1]].kind {
+	case kindTuple, kindList:
+	default:
+		return View{}, false
+	}
+	for j < len(v.data) && v.data[j] != 0x00 {
+		c, _ := viewAt(v.data[j:])
+		if i == 0 {
+			return c, true
+		}
+		i--
+		j += c.Len()
+	}
+	return View{}, false
+}
+
+// Value returns the value, sharing memory with the view where the encoding
+// allows it.
+func (v View) Value() *Value {
+	h := v.header()
+	val := &Value{Code: v.data[h], Level: h, Bytes: v.Bytes()}
+	for _, c := range v.Children() {
+		val.Values = append(val.Values, c.Value())
+	}
+	return val
+}
@@ -0,0 +1,100 @@
+package bone
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMapped(t *testing.T) {
+	values := []*Value{
+		List(Str("ref"), Tuple(Int(1), Raw([]byte("plain"))), Level(1, True)),
+		Raw([]byte{0x00, 0x01, 0x00}),
+		NewInt(-70000),
+		List(),
+	}
+	path := filepath.Join(t.TempDir(), "data.bone")
+	if err := os.WriteFile(path, Encode(values), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := OpenMapped(path)
+	if err != nil {
+		t.Fatalf("Failed to map file: %v", err)
+	}
+	defer m.Close()
+
+	off := 0
+	for i, want := range values {
+		v, err := m.At(off)
+		if err != nil {
+			t.Fatalf("Value %d: unexpected error: %v", i, err)
+		}
+		if !bytes.Equal(v.Encoded(), Encode([]*Value{want})) {
+			t.Errorf("Value %d: expected % X, got % X", i, Encode([]*Value{want}), v.Encoded())
+		}
+		if Compare(v.Value(), want) != 0 {
+			t.Errorf("Value %d: decoded value differs", i)
+		}
+		off += v.Len()
+	}
+	if _, err := m.At(off); err == nil {
+		t.Error("Expected error past the end of the file")
+	}
+
+	first, _ := m.At(0)
+	tuple, ok := first.Child(1)
+	if !ok || tuple.Code() != 0xB0 || len(tuple.Children()) != 2 {
+		t.Fatalf("Expected tuple as second child, got % X", tuple.Encoded())
+	}
+	s, _ := tuple.Child(1)
+	b := s.Bytes()
+	if string(b) != "plain" || !inside(b, m.Data()) {
+		t.Errorf("Expected zero-copy bytes, got %q", b)
+	}
+	if level, _ := first.Child(2); level.Level() != 1 || level.Code() != CodeTrue {
+		t.Errorf("Expected level 1 true, got % X", level.Encoded())
+	}
+	if _, ok := first.Child(3); ok {
+		t.Error("Expected no fourth child")
+	}
+	if _, ok := s.Child(0); ok {
+		t.Error("Expected no children of a string")
+	}
+	escaped, _ := m.At(first.Len())
+	if b := escaped.Bytes(); !bytes.Equal(b, []byte{0x00, 0x01, 0x00}) {
+		t.Errorf("Expected unescaped bytes, got % X", b)
+	}
+}
+
+func inside(b, data []byte) bool {
+	return len(b) > 0 && &b[0] == &data[bytes.Index(data, b)]
+}
+
+func TestMappedBytesAppend(t *testing.T) {
+	values := []*Value{Raw([]byte("abc")), NewInt(300), Raw([]byte("def"))}
+	enc := Encode(values)
+	path := filepath.Join(t.TempDir(), "data.bone")
+	if err := os.WriteFile(path, enc, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := OpenMapped(path)
+	if err != nil {
+		t.Fatalf("Failed to map file: %v", err)
+	}
+	defer m.Close()
+
+	// Appending must copy rather than write into the read-only mapping.
+	for off := 0; off < len(m.Data()); {
+		v, err := m.At(off)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = append(v.Value().Bytes, 0xAA)
+		_ = append(v.Encoded(), 0xAA)
+		off += v.Len()
+	}
+	if !bytes.Equal(m.Data(), enc) {
+		t.Errorf("Expected mapping to be unchanged, got % X", m.Data())
+	}
+}
@@ -0,0 +1,164 @@
+package bone
+
+import (
+	"bytes"
+	"container/heap"
+)
+
+// Iterator is a stream of entries in increasing order of their encoded keys,
+// such as a table scan. Key and Value return encodings that are only valid
+// until the next call to Next.
+type Iterator interface {
+	Next() bool
+	Key() []byte
+	Value() []byte
+	Err() error
+}
+
+// Resolver decides which values are emitted for a key held by more than one
+// entry. values are the entries' values in source order, and for entries
+// from the same source, in the order the source yielded them. Returning no
+// values drops the key.
+type Resolver func(key []byte, values [][]byte) [][]byte
+
+// LastWins keeps only the value from the latest source, as when later
+// sources hold newer writes.
+func LastWins(key []byte, values [][]byte) [][]byte {
+	return values[len(values)-1:]
+}
+
+// KeepAll emits every value, so the key repeats once per entry.
+func KeepAll(key []byte, values [][]byte) [][]byte {
+	return values
+}
+
+// MergeIterator merges sorted sources into a single sorted stream. It is an
+// Iterator itself, so merges can be stacked.
+type MergeIterator struct {
+	// Resolve is applied to every key, including those held by a single
+	// entry. A nil Resolve is KeepAll.
+	Resolve Resolver
+
+	heap    mergeHeap
+	started bool
+	key     []byte
+	values  [][]byte
+	out     [][]byte
+	err     error
+}
+
+// MergeIter returns an iterator over the entries of all sources in key order.
+// Entries with equal keys are grouped and passed to Resolve.
+func MergeIter(sources ...Iterator) *MergeIterator {
+	m := &MergeIterator{}
+	for i, src := range sources {
+		m.heap = append(m.heap, &mergeSource{it: src, index: i})
+	}
+	return m
+}
+
+type mergeSource struct {
+	it    Iterator
+	index int
+}
+
+type mergeHeap []*mergeSource
+
+func (h mergeHeap) Len() int      { return len(h) }
+func (h mergeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h mergeHeap) Less(i, j int) bool {
+	if c := bytes.Compare(h[i].it.Key(), h[j].it.Key()); c != 0 {
+		return c < 0
+	}
+	return h[i].index < h[j].index
+}
+func (h *mergeHeap) Push(x any) { *h = append(*h, x.(*mergeSource)) }
+func (h *mergeHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// advance moves the source at the top of the heap to its next entry,
+// removing it once exhausted.
+func (m *MergeIterator) advance() bool {
+	s := m.heap[0]
+	if s.it.Next() {
+		heap.Fix(&m.heap, 0)
+		return true
+	}
+	if err := s.it.Err(); err != nil {
+		m.err = err
+		return false
+	}
+	heap.Pop(&m.heap)
+	return true
+}
+
+func (m *MergeIterator) Next() bool {
+	if m.err != nil {
+		return false
+	}
+	if !m.started {
+		m.started = true
+		sources := m.heap
+		m.heap = m.heap[:0]
+		for _, s := range sources {
+			if s.it.Next() {
+				m.heap = append(m.heap, s)
+			} else if err := s.it.Err(); err != nil {
+				m.err = err
+				return false
+			}
+		}
+		heap.Init(&m.heap)
+	}
+	if len(m.out) > 0 {
+		m.out = m.out[1:]
+	}
+	for len(m.out) == 0 {
+		if len(m.heap) == 0 {
+			return false
+		}
+		// Copy each entry of the group before its source moves on.
+		m.key = append(m.key[:0], m.heap[0].it.Key()...)
+		n := 0
+		for len(m.heap) > 0 && bytes.Equal(m.heap[0].it.Key(), m.key) {
+			if n < len(m.values) {
+				m.values[n] = append(m.values[n][:0], m.heap[0].it.Value()...)
+			} else {
+				m.values = append(m.values, bytes.Clone(m.heap[0].it.Value()))
+			}
+			n++
+			if !m.advance() {
+				return false
+			}
+		}
+		resolve := m.Resolve
+		if resolve == nil {
+			resolve = KeepAll
+		}
+		m.out = resolve(m.key, m.values[:n])
+	}
+	return true
+}
+
+func (m *MergeIterator) Key() []byte {
+	if len(m.out) == 0 {
+		return nil
+	}
+	return m.key
+}
+
+func (m *MergeIterator) Value() []byte {
+	if len(m.out) == 0 {
+		return nil
+	}
+	return m.out[0]
+}
+
+// Err returns the first error reported by a source.
+func (m *MergeIterator) Err() error {
+	return m.err
+}
@@ -0,0 +1,108 @@
+package bone
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type sliceIterator struct {
+	entries [][2]*Value
+	i       int
+	err     error
+}
+
+func (s *sliceIterator) Next() bool {
+	s.i++
+	return s.i <= len(s.entries)
+}
+
+func (s *sliceIterator) Key() []byte   { return Encode(s.entries[s.i-1][:1]) }
+func (s *sliceIterator) Value() []byte { return Encode(s.entries[s.i-1][1:]) }
+func (s *sliceIterator) Err() error {
+	if s.i > len(s.entries) {
+		return s.err
+	}
+	return nil
+}
+
+func entries(kvs ...int64) *sliceIterator {
+	s := &sliceIterator{}
+	for i := 0; i < len(kvs); i += 2 {
+		s.entries = append(s.entries, [2]*Value{NewInt(kvs[i]), NewInt(kvs[i+1])})
+	}
+	return s
+}
+
+func collect(t *testing.T, it Iterator) string {
+	var out []string
+	for it.Next() {
+		k, _ := Decode(it.Key())
+		v, _ := Decode(it.Value())
+		ki, _ := k[0].Int()
+		vi, _ := v[0].Int()
+		out = append(out, fmt.Sprintf("%d=%d", ki, vi))
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return fmt.Sprint(out)
+}
+
+func TestMergeIter(t *testing.T) {
+	sources := func() []Iterator {
+		return []Iterator{
+			entries(1, 10, 3, 10, 5, 10, 300, 10),
+			entries(),
+			entries(-1, 20, 3, 20, 4, 20, 5, 20),
+			entries(3, 30, 3, 31),
+		}
+	}
+	cases := []struct {
+		resolve  Resolver
+		expected string
+	}{
+		{nil, "[-1=20 1=10 3=10 3=20 3=30 3=31 4=20 5=10 5=20 300=10]"},
+		{KeepAll, "[-1=20 1=10 3=10 3=20 3=30 3=31 4=20 5=10 5=20 300=10]"},
+		{LastWins, "[-1=20 1=10 3=31 4=20 5=20 300=10]"},
+		{func(key []byte, values [][]byte) [][]byte {
+			if len(values) > 1 {
+				return nil
+			}
+			return values
+		}, "[-1=20 1=10 4=20 300=10]"},
+	}
+	for i, c := range cases {
+		m := MergeIter(sources()...)
+		m.Resolve = c.resolve
+		if got := collect(t, m); got != c.expected {
+			t.Errorf("Case %d: expected %s, got %s", i, c.expected, got)
+		}
+	}
+
+	nested := MergeIter(MergeIter(entries(1, 1), entries(2, 2)), entries(1, 3))
+	nested.Resolve = LastWins
+	if got := collect(t, nested); got != "[1=3 2=2]" {
+		t.Errorf("Expected nested merge [1=3 2=2], got %s", got)
+	}
+	if MergeIter().Next() {
+		t.Error("Expected empty merge")
+	}
+}
+
+func TestMergeIterError(t *testing.T) {
+	failing := entries(2, 2)
+	failing.err = errors.New("read failed")
+	m := MergeIter(entries(1, 1, 3, 3), failing)
+	var keys [][]byte
+	for m.Next() {
+		keys = append(keys, bytes.Clone(m.Key()))
+	}
+	if !errors.Is(m.Err(), failing.err) {
+		t.Errorf("Expected source error, got %v", m.Err())
+	}
+	if len(keys) > 1 {
+		t.Errorf("Expected iteration to stop at the error, got %d entries", len(keys))
+	}
+}
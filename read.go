@@ -0,0 +1,151 @@
+package bone
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// The Read functions decode a single level 0 primitive from the start of src
+// and report how many bytes it used. They are the counterparts of the Append
+// functions.
+
+func ReadCode(src []byte, code byte) (int, error) {
+	if len(src) == 0 || src[0] != code {
+		return 0, errors.New("unexpected type code")
+	}
+	return 1, nil
+}
+
+func ReadInt(src []byte) (int64, int, error) {
+	if len(src) == 0 {
+		return 0, 0, errors.New("not an int")
+	}
+	code := src[0]
+	if code >= 0x10 && code < 0x18 {
+		return int64(code - 0x10), 1, nil
+	}
+	var n int
+	switch {
+	case code >= 0x08 && code < 0x10:
+		n = int(0x10 - code)
+	case code >= 0x18 && code < 0x20:
+		n = int(code - 0x17)
+	default:
+		return 0, 0, errors.New("not an int")
+	}
+	if len(src) < 1+n {
+		return 0, 0, errors.New("truncated int")
+	}
+	i, err := intFromBytes(code, src[1:1+n])
+	return i, 1 + n, err
+}
+
+func intFromBytes(code byte, b []byte) (int64, error) {
+	var buf [8]byte
+	copy(buf[8-len(b):], b)
+	u := binary.BigEndian.Uint64(buf[:])
+	if code >= 0x18 {
+		if u > 1<<63-1 {
+			return 0, errors.New("int overflow")
+		}
+		return int64(u), nil
+	}
+	n := len(b)
+	if n == 8 {
+		if u < 1<<63 {
+			return 0, errors.New("int overflow")
+		}
+		return int64(u), nil
+	}
+	return int64(u) - 1<<(8*n), nil
+}
+
+func ReadBool(src []byte) (bool, int, error) {
+	if len(src) > 0 && src[0] == CodeTrue {
+		return true, 1, nil
+	}
+	if len(src) > 0 && src[0] == CodeFalse {
+		return false, 1, nil
+	}
+	return false, 0, errors.New("not a bool")
+}
+
+func ReadFloat(src []byte) (float64, int, error) {
+	if len(src) < 9 || src[0] != CodeFloat {
+		return 0, 0, errors.New("not a float")
+	}
+	return floatFromBits(binary.BigEndian.Uint64(src[1:9])), 9, nil
+}
+
+func ReadText(src []byte) (string, int, error) {
+	if len(src) == 0 || src[0] != CodeText {
+		return "", 0, errors.New("not text")
+	}
+	raw, n, escaped, err := scanString(src)
+	if err != nil || !escaped {
+		return string(raw), n, err
+	}
+	var b strings.Builder
+	b.Grow(len(raw))
+	unescape(raw, func(seg []byte) { b.Write(seg) })
+	return b.String(), n, nil
+}
+
+// ReadBytes returns a copy of the bytes string at the start of src.
+func ReadBytes(src []byte) ([]byte, int, error) {
+	return ReadBytesInto([]byte{}, src)
+}
+
+// ReadBytesInto is ReadBytes appending the bytes to dst, so decoding into a
+// reused buffer does not allocate.
+func ReadBytesInto(dst, src []byte) ([]byte, int, error) {
+	if len(src) == 0 || src[0] != CodeBytes {
+		return nil, 0, errors.New("not bytes")
+	}
+	raw, n, escaped, err := scanString(src)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !escaped {
+		return append(dst, raw...), n, nil
+	}
+	unescape(raw, func(seg []byte) { dst = append(dst, seg...) })
+	return dst, n, nil
+}
+
+// scanString finds the end of the string at the start of src. It returns the
+// string's bytes as encoded, a subslice of src, the encoded length, and
+// whether the bytes hold escaped nulls that unescape must undo.
+func scanString(src []byte) ([]byte, int, bool, error) {
+	escaped := false
+	for i := 1; i < len(src); {
+		j := bytes.IndexByte(src[i:], 0x00)
+		if j < 0 {
+			break
+		}
+		i += j
+		if i+1 < len(src) && src[i+1] == 0x01 {
+			escaped = true
+			i += 2
+			continue
+		}
+		return src[1:i], i + 1, escaped, nil
+	}
+	return nil, 0, false, errors.New("unterminated string")
+}
+
+// unescape passes raw to write in segments, with each escaped 00 01 turned
+// back into 00.
+func unescape(raw []byte, write func([]byte)) {
+	for {
+		i := bytes.IndexByte(raw, 0x00)
+		if i < 0 {
+			write(raw)
+			return
+		}
+		write(raw[:i+1])
+		raw = raw[i+2:]
+	}
+}
@@ -0,0 +1,97 @@
+package bone
+
+import (
+	"bytes"
+	"errors"
+)
+
+// Skipped is a byte range DecodeRecover passed over, with the error that
+// caused it to resynchronize.
+type Skipped struct {
+	Start int
+	End   int
+	Err   error
+}
+
+// resyncRun is how many consecutive values must decode from a candidate
+// offset, without a marker, before it is trusted as a value boundary.
+const resyncRun = 2
+
+// resyncWindow caps the bytes examined from each candidate offset, so that
+// resynchronizing without a marker takes time linear in the damaged range
+// rather than rescanning to the end of data from every offset.
+const resyncWindow = 4 << 10
+
+// DecodeRecover decodes a sequence of top level values like Decode, but on
+// error it skips forward to the next plausible value boundary and carries on,
+// reporting every damaged range it passed over.
+//
+// When marker is non-empty every value is expected to be preceded by it, as
+// written by a producer that frames records with a sync marker, and recovery
+// resumes at the next occurrence of the marker. Otherwise recovery resumes at
+// the first later offset from which a run of values decodes cleanly within a
+// few kilobytes, so a value longer than that directly after a damaged range
+// is only recovered if it lies at the end of data.
+func DecodeRecover(data []byte, marker []byte) ([]*Value, []Skipped) {
+	var values []*Value
+	var skipped []Skipped
+	pos := 0
+	for pos < len(data) {
+		n, err := recordLen(data[pos:], marker)
+		if err == nil {
+			vs, derr := Decode(data[pos+len(marker) : pos+n])
+			if derr == nil {
+				values = append(values, vs...)
+				pos += n
+				continue
+			}
+			err = derr
+		}
+		next := resync(data, pos+1, marker)
+		if l := len(skipped); l > 0 && skipped[l-1].End == pos {
+			skipped[l-1].End = next
+		} else {
+			skipped = append(skipped, Skipped{Start: pos, End: next, Err: err})
+		}
+		pos = next
+	}
+	return values, skipped
+}
+
+func recordLen(data []byte, marker []byte) (int, error) {
+	if !bytes.HasPrefix(data, marker) {
+		return 0, errors.New("missing sync marker")
+	}
+	n, err := Skip(data[len(marker):])
+	return len(marker) + n, err
+}
+
+func resync(data []byte, from int, marker []byte) int {
+	if len(marker) > 0 {
+		i := bytes.Index(data[from:], marker)
+		if i < 0 {
+			return len(data)
+		}
+		return from + i
+	}
+	for p := from; p < len(data); p++ {
+		if plausible(data[p:min(p+resyncWindow, len(data))]) {
+			return p
+		}
+	}
+	return len(data)
+}
+
+func plausible(data []byte) bool {
+	for range resyncRun {
+		if len(data) == 0 {
+			return true
+		}
+		n, err := Skip(data)
+		if err != nil {
+			return false
+		}
+		data = data[n:]
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package bone
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDecodeRecover(t *testing.T) {
+	good := []*Value{
+		List(Str("first"), Int(1)),
+		Tuple(Str("second"), Int(2)),
+		List(Str("third"), Int(3)),
+		Tuple(Str("fourth"), Int(4)),
+	}
+	var data []byte
+	var offsets []int
+	for _, v := range good {
+		offsets = append(offsets, len(data))
+		data = AppendEncode(data, v)
+	}
+	corrupt := bytes.Clone(data)
+	corrupt[offsets[1]] = 0x03
+
+	values, skipped := DecodeRecover(corrupt, nil)
+	if len(skipped) != 1 {
+		t.Fatalf("Expected 1 skipped range, got %v", skipped)
+	}
+	if skipped[0].Start != offsets[1] || skipped[0].End > offsets[2] || skipped[0].Err == nil {
+		t.Errorf("Expected skipped range from %d to at most %d, got %+v", offsets[1], offsets[2], skipped[0])
+	}
+	if len(values) < 3 || Compare(values[0], good[0]) != 0 || Compare(values[len(values)-1], good[3]) != 0 {
+		t.Errorf("Expected first and last values to be recovered, got %d values", len(values))
+	}
+
+	torn := data[:len(data)-3]
+	values, skipped = DecodeRecover(torn, nil)
+	if len(skipped) == 0 || skipped[0].Start != offsets[3] {
+		t.Fatalf("Expected torn tail from %d to be skipped, got %+v", offsets[3], skipped)
+	}
+	for i := range 3 {
+		if Compare(values[i], good[i]) != 0 {
+			t.Errorf("Value %d: expected it to be recovered intact", i)
+		}
+	}
+}
+
+func TestDecodeRecoverMarker(t *testing.T) {
+	marker := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x9E, 0x00}
+	var data []byte
+	var offsets []int
+	for i := range 5 {
+		offsets = append(offsets, len(data))
+		data = append(data, marker...)
+		data = AppendEncode(data, List(Str("record"), Int(int64(i)), Raw([]byte{0x00, 0x01})))
+	}
+	corrupt := bytes.Clone(data)
+	corrupt[offsets[1]+len(marker)+3] = 0x00
+	corrupt[offsets[1]+len(marker)+4] = 0x05
+	copy(corrupt[offsets[3]:], []byte{0x01, 0x02})
+
+	values, skipped := DecodeRecover(corrupt, marker)
+	if len(values) != 3 {
+		t.Fatalf("Expected 3 recovered values, got %d", len(values))
+	}
+	for i, want := range []int64{0, 2, 4} {
+		if got, _ := values[i].Values[1].Int(); got != want {
+			t.Errorf("Value %d: expected record %d, got %d", i, want, got)
+		}
+	}
+	expected := []Skipped{{Start: offsets[1], End: offsets[2]}, {Start: offsets[3], End: offsets[4]}}
+	if len(skipped) != len(expected) {
+		t.Fatalf("Expected %d skipped ranges, got %+v", len(expected), skipped)
+	}
+	for i, s := range skipped {
+		if s.Start != expected[i].Start || s.End != expected[i].End {
+			t.Errorf("Skipped %d: expected %d-%d, got %d-%d", i, expected[i].Start, expected[i].End, s.Start, s.End)
+		}
+	}
+}
+
+func TestDecodeRecoverLongDamage(t *testing.T) {
+	// Every offset of the damage starts an unterminated string, which an
+	// unbounded scan would follow to the end of data from each of them.
+	damage := bytes.Repeat([]byte{0x91}, 1<<20)
+	data := AppendEncode(nil, Int(1))
+	data = append(data, damage...)
+
+	values, skipped := DecodeRecover(data, nil)
+	if len(values) != 1 || Compare(values[0], Int(1)) != 0 {
+		t.Errorf("Expected the leading value to be recovered, got %d values", len(values))
+	}
+	if len(skipped) != 1 || skipped[0].Start != len(data)-len(damage) || skipped[0].End != len(data) {
+		t.Errorf("Expected the damage to be skipped, got %+v", skipped)
+	}
+}
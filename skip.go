@@ -0,0 +1,84 @@
+package bone
+
+import (
+	"bytes"
+	"errors"
+)
+
+// ErrTruncated is returned by Skip when data ends before the value does, so a
+// caller reading a stream can tell that more input is needed.
+var ErrTruncated = errors.New("truncated value")
+
+// Skip returns the encoded length of the first value in data without
+// decoding it. It validates the same structure as Decode, so it fails on
+// illegal codes and on values that are cut short. A string is complete once
+// its terminating 0x00 is followed by something other than 0x01 or by the
+// end of data.
+func Skip(data []byte) (int, error) {
+	var stack []int
+	i := 0
+	for {
+		level := 0
+		for i < len(data) && data[i] == 0xFF {
+			level++
+			i++
+		}
+		if i >= len(data) {
+			return 0, ErrTruncated
+		}
+		top := len(stack) - 1
+		if top >= 0 && stack[top] < 0 && data[i] == 0x00 {
+			if level != 0 {
+				return 0, errors.New("list terminated with non-zero level")
+			}
+			i++
+			stack = stack[:top]
+		} else {
+			code := data[i]
+			info := codeTable[code]
+			if info.kind == kindIllegal {
+				return 0, errors.New("illegal type code")
+			}
+			if level > 0 && code < 0x20 {
+				return 0, errors.New("illegal level extension")
+			}
+			i++
+			switch info.kind {
+			case kindBlock:
+				i += int(info.width)
+				if i > len(data) {
+					return 0, ErrTruncated
+				}
+			case kindString:
+				for {
+					j := bytes.IndexByte(data[i:], 0x00)
+					if j < 0 {
+						return 0, ErrTruncated
+					}
+					i += j + 1
+					if i < len(data) && data[i] == 0x01 {
+						i++
+						continue
+					}
+					break
+				}
+			case kindTuple:
+				stack = append(stack, int(info.arity))
+				continue
+			case kindList:
+				stack = append(stack, -1)
+				continue
+			}
+		}
+		for len(stack) > 0 && stack[len(stack)-1] > 0 {
+			stack[len(stack)-1]--
+			if stack[len(stack)-1] > 0 {
+				break
+			}
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			return i, nil
+		}
+	}
+}
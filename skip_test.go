@@ -0,0 +1,76 @@
+package bone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSkip(t *testing.T) {
+	for i, data := range DecodableSeedCorpus {
+		t.Run(fmt.Sprintf("DecodableSeedCorpus[%d]", i), func(t *testing.T) {
+			values, err := Decode(data)
+			if err != nil {
+				t.Fatalf("Failed to decode: %v", err)
+			}
+			rest := data
+			for j, v := range values {
+				n, err := Skip(rest)
+				if err != nil {
+					t.Fatalf("Value %d: failed to skip: %v", j, err)
+				}
+				if n != EncodedLen(v) {
+					t.Errorf("Value %d: expected length %d, got %d", j, EncodedLen(v), n)
+				}
+				rest = rest[n:]
+			}
+		})
+	}
+}
+
+func TestSkipIllegal(t *testing.T) {
+	illegal := [][]byte{
+		{},
+		{0x00},
+		{0xFF},
+		{0xFF, 0x10},
+		{0x40, 0xAA},
+		{0x91, 0x41},
+		{0xB0, 0x20},
+		{0xF0, 0x20},
+		{0xF0, 0xFF, 0x00},
+		{0xF0, 0x07, 0x00},
+	}
+	for _, data := range illegal {
+		if _, err := Skip(data); err == nil {
+			t.Errorf("Expected error skipping % X", data)
+		}
+	}
+	truncated := map[string][]byte{"block": {0x40, 0xAA}, "string": {0x91, 0x41}, "list": {0xF0, 0x20}, "level": {0xFF}}
+	for name, data := range truncated {
+		if _, err := Skip(data); err != ErrTruncated {
+			t.Errorf("Expected ErrTruncated for %s, got %v", name, err)
+		}
+	}
+	if _, err := Skip([]byte{0xF0, 0x07, 0x00}); err == ErrTruncated {
+		t.Errorf("Expected illegal code not to be reported as truncation")
+	}
+}
+
+func FuzzSkip(f *testing.F) {
+	for _, data := range DecodableSeedCorpus {
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		n, err := Skip(payload)
+		if err != nil {
+			return
+		}
+		values, err := Decode(payload[:n])
+		if err != nil {
+			t.Fatalf("Skip accepted % X which does not decode: %v", payload[:n], err)
+		}
+		if len(values) != 1 {
+			t.Fatalf("Skip returned %d bytes holding %d values", n, len(values))
+		}
+	})
+}
@@ -0,0 +1,270 @@
+// Package sort sorts streams of BONE values by their encoding, which is the
+// order of bone.Compare, using bounded memory.
+//
+// Values are never decoded: their encodings are split apart with bone.Skip
+// and held in memory until they exceed the memory budget, then sorted and
+// spilled as a run to a temporary file. Once the input is exhausted the runs
+// are merged with a heap, so any input size can be sorted with one pass over
+// the data and one over the runs.
+//
+// Runs are written as the raw encodings rather than through the streaming
+// bone.Writer: re-encoding would need the values decoded, and their decoded
+// size is what made the memory budget inexact.
+package sort
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"errors"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+// DefaultMemoryBudget is the memory budget used when Options.MemoryBudget is
+// zero.
+const DefaultMemoryBudget = 64 << 20
+
+// entrySize is the memory each value costs beyond its encoding: its span in
+// the sorter's buffer.
+const entrySize = 16
+
+type Options struct {
+	// MemoryBudget is the memory held by values before a run is spilled:
+	// their encodings plus 16 bytes of bookkeeping each.
+	MemoryBudget int
+	// Dedup drops values whose encoding equals that of the previous value.
+	Dedup bool
+	// TempDir is the directory for runs, os.TempDir() if empty.
+	TempDir string
+}
+
+// Sort reads every value from src and writes them to dst in sorted order.
+func Sort(dst io.Writer, src io.Reader, opts Options) error {
+	if opts.MemoryBudget <= 0 {
+		opts.MemoryBudget = DefaultMemoryBudget
+	}
+	s := &sorter{opts: opts}
+	defer s.cleanup()
+
+	in := &scanner{r: src}
+	for {
+		enc, err := in.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		s.spans = append(s.spans, span{len(s.data), len(s.data) + len(enc)})
+		s.data = append(s.data, enc...)
+		if len(s.data)+entrySize*len(s.spans) >= opts.MemoryBudget {
+			if err := s.spill(); err != nil {
+				return err
+			}
+		}
+	}
+
+	w := bufio.NewWriter(dst)
+	if len(s.runs) == 0 {
+		if err := s.writeSorted(w); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+	if len(s.spans) > 0 {
+		if err := s.spill(); err != nil {
+			return err
+		}
+	}
+	if err := s.merge(w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// span locates an encoding in sorter.data.
+type span struct {
+	start, end int
+}
+
+type sorter struct {
+	opts  Options
+	data  []byte
+	spans []span
+	runs  []*os.File
+}
+
+func (s *sorter) key(sp span) []byte {
+	return s.data[sp.start:sp.end]
+}
+
+// writeSorted sorts the buffered values, writes them to w and empties the
+// buffer.
+func (s *sorter) writeSorted(w io.Writer) error {
+	slices.SortFunc(s.spans, func(a, b span) int {
+		return bytes.Compare(s.key(a), s.key(b))
+	})
+	for i, sp := range s.spans {
+		if s.opts.Dedup && i > 0 && bytes.Equal(s.key(s.spans[i-1]), s.key(sp)) {
+			continue
+		}
+		if _, err := w.Write(s.key(sp)); err != nil {
+			return err
+		}
+	}
+	s.data, s.spans = s.data[:0], s.spans[:0]
+	return nil
+}
+
+func (s *sorter) spill() error {
+	f, err := os.CreateTemp(s.opts.TempDir, "bone-sort-*")
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, f)
+	w := bufio.NewWriter(f)
+	if err := s.writeSorted(w); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	return err
+}
+
+func (s *sorter) cleanup() {
+	for _, f := range s.runs {
+		f.Close()
+		os.Remove(f.Name())
+	}
+}
+
+// scanner splits a stream into the encodings of its values.
+type scanner struct {
+	r     io.Reader
+	buf   []byte
+	start int
+	eof   bool
+}
+
+// next returns the encoding of the next value, valid until the following
+// call, or io.EOF once the input ends between values.
+func (s *scanner) next() ([]byte, error) {
+	for {
+		rest := s.buf[s.start:]
+		n, err := bone.Skip(rest)
+		// A value that reaches the end of the buffer may be a string whose
+		// next byte is an escape, so it is only taken once more input or the
+		// end of input follows it.
+		if err == nil && (n < len(rest) || s.eof) {
+			s.start += n
+			return rest[:n], nil
+		}
+		if err != nil && !errors.Is(err, bone.ErrTruncated) {
+			return nil, err
+		}
+		if s.eof {
+			if len(rest) == 0 {
+				return nil, io.EOF
+			}
+			return nil, err
+		}
+		if err := s.fill(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// fill moves the unread bytes to the front of the buffer and reads at least
+// as many again, so a long value is rescanned only a logarithmic number of
+// times.
+func (s *scanner) fill() error {
+	rest := copy(s.buf, s.buf[s.start:])
+	s.buf, s.start = s.buf[:rest], 0
+	want := max(rest, 64<<10)
+	s.buf = slices.Grow(s.buf, want)
+	n, err := io.ReadAtLeast(s.r, s.buf[rest:rest+want], 1)
+	s.buf = s.buf[:rest+n]
+	if err == io.EOF {
+		s.eof = true
+		return nil
+	}
+	return err
+}
+
+type run struct {
+	s    *scanner
+	head []byte
+}
+
+// runHeap orders runs by their head encoding.
+type runHeap []*run
+
+func (h runHeap) Len() int      { return len(h) }
+func (h runHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h runHeap) Less(i, j int) bool {
+	return bytes.Compare(h[i].head, h[j].head) < 0
+}
+func (h *runHeap) Push(x any) { *h = append(*h, x.(*run)) }
+func (h *runHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// advance reads the next encoding of the run. It returns false once the run
+// is exhausted.
+func (r *run) advance() (bool, error) {
+	enc, err := r.s.next()
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	r.head = enc
+	return true, nil
+}
+
+func (s *sorter) merge(w io.Writer) error {
+	h := make(runHeap, 0, len(s.runs))
+	for _, f := range s.runs {
+		r := &run{s: &scanner{r: f}}
+		ok, err := r.advance()
+		if err != nil {
+			return err
+		}
+		if ok {
+			h = append(h, r)
+		}
+	}
+	heap.Init(&h)
+	var last []byte
+	for i := 0; len(h) > 0; i++ {
+		r := h[0]
+		if !s.opts.Dedup || i == 0 || !bytes.Equal(r.head, last) {
+			if _, err := w.Write(r.head); err != nil {
+				return err
+			}
+			if s.opts.Dedup {
+				last = append(last[:0], r.head...)
+			}
+		}
+		ok, err := r.advance()
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+	return nil
+}
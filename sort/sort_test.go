@@ -0,0 +1,90 @@
+package sort
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"testing"
+	"testing/iotest"
+
+	"github.com/mrmcc3/bone-go"
+	"github.com/mrmcc3/bone-go/internal/benchdata"
+)
+
+func testInput() []*bone.Value {
+	values := benchdata.FlatInts(2000)
+	values = append(values, benchdata.FlatInts(500)...)
+	for i := range 300 {
+		values = append(values, bone.Tuple(bone.Str("key"), bone.Int(int64(i%50))))
+	}
+	return values
+}
+
+func expected(values []*bone.Value, dedup bool) []byte {
+	var keys [][]byte
+	for _, v := range values {
+		keys = append(keys, bone.Encode([]*bone.Value{v}))
+	}
+	slices.SortFunc(keys, bytes.Compare)
+	if dedup {
+		keys = slices.CompactFunc(keys, bytes.Equal)
+	}
+	return bytes.Join(keys, nil)
+}
+
+func TestSort(t *testing.T) {
+	values := testInput()
+	input := bone.Encode(values)
+	for _, budget := range []int{0, 256, 4096} {
+		for _, dedup := range []bool{false, true} {
+			dir := t.TempDir()
+			var out bytes.Buffer
+			err := Sort(&out, bytes.NewReader(input), Options{MemoryBudget: budget, Dedup: dedup, TempDir: dir})
+			if err != nil {
+				t.Fatalf("Budget %d dedup %v: unexpected error: %v", budget, dedup, err)
+			}
+			if !bytes.Equal(out.Bytes(), expected(values, dedup)) {
+				t.Errorf("Budget %d dedup %v: output is not sorted", budget, dedup)
+			}
+			if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+				t.Errorf("Budget %d dedup %v: expected runs to be removed, found %d", budget, dedup, len(entries))
+			}
+		}
+	}
+}
+
+func TestSortSplitsStreamedInput(t *testing.T) {
+	values := []*bone.Value{
+		bone.Raw([]byte{0x00, 0x00}),
+		bone.Raw([]byte{0x00}),
+		bone.Str("b"),
+		bone.Raw(nil),
+		bone.List(bone.Raw([]byte{0x00}), bone.Level(1, bone.True)),
+		bone.Str("a"),
+	}
+	var out bytes.Buffer
+	err := Sort(&out, iotest.OneByteReader(bytes.NewReader(bone.Encode(values))), Options{MemoryBudget: 40, TempDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), expected(values, false)) {
+		t.Errorf("Expected % X, got % X", expected(values, false), out.Bytes())
+	}
+}
+
+func TestSortErrors(t *testing.T) {
+	var out bytes.Buffer
+	if err := Sort(&out, bytes.NewReader(nil), Options{}); err != nil || out.Len() != 0 {
+		t.Errorf("Expected empty output for empty input, got %d bytes and %v", out.Len(), err)
+	}
+	if err := Sort(&out, bytes.NewReader([]byte{0x11, 0x10, 0xF0}), Options{}); err == nil {
+		t.Error("Expected error for truncated input")
+	}
+	if err := Sort(&out, bytes.NewReader([]byte{0x11, 0x03, 0x10}), Options{}); err == nil {
+		t.Error("Expected error for illegal code")
+	}
+	err := Sort(&out, bytes.NewReader([]byte{0x11, 0x10}), Options{MemoryBudget: 1, TempDir: "/nonexistent/dir"})
+	if err == nil {
+		t.Error("Expected error for missing temp dir")
+	}
+}
@@ -0,0 +1,329 @@
+// Package sstable implements an immutable sorted table of BONE key/value
+// pairs.
+//
+// A table is a sequence of data blocks, an index and a fixed size footer. A
+// data block holds entries back to back, each the encoded key followed by the
+// encoded value; both are self-delimiting so no lengths are stored. The index
+// holds, for every block, the encoded first key followed by the block offset
+// and length as ints. The footer is the index offset and length as 8 byte
+// big-endian integers followed by Magic.
+//
+// Keys are ordered by their encoding, which is the order of bone.Compare.
+package sstable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"slices"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+const (
+	Magic      = "bonesst1"
+	FooterSize = 16 + len(Magic)
+	// DefaultBlockSize is the size a data block grows to before the next
+	// entry starts a new one.
+	DefaultBlockSize = 4096
+)
+
+var (
+	ErrOutOfOrder = errors.New("sstable: keys must be added in increasing order")
+	ErrCorrupt    = errors.New("sstable: corrupt table")
+)
+
+type Writer struct {
+	BlockSize int
+
+	w      io.Writer
+	offset int64
+	block  []byte
+	first  []byte
+	last   []byte
+	index  []byte
+	err    error
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{BlockSize: DefaultBlockSize, w: w}
+}
+
+// Add appends an entry. Keys must be added in strictly increasing order.
+func (w *Writer) Add(key, value *bone.Value) error {
+	if w.err != nil {
+		return w.err
+	}
+	start := len(w.block)
+	w.block = bone.AppendEncode(w.block, key)
+	k := w.block[start:]
+	if w.last != nil && bytes.Compare(k, w.last) <= 0 {
+		w.block = w.block[:start]
+		return ErrOutOfOrder
+	}
+	w.last = append(w.last[:0], k...)
+	if start == 0 {
+		w.first = append(w.first[:0], k...)
+	}
+	w.block = bone.AppendEncode(w.block, value)
+	if len(w.block) >= w.BlockSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *Writer) flush() error {
+	if len(w.block) == 0 {
+		return nil
+	}
+	w.index = append(w.index, w.first...)
+	w.index = bone.AppendInt(w.index, w.offset)
+	w.index = bone.AppendInt(w.index, int64(len(w.block)))
+	if err := w.write(w.block); err != nil {
+		return err
+	}
+	w.block = w.block[:0]
+	return nil
+}
+
+func (w *Writer) write(b []byte) error {
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	if err != nil {
+		w.err = err
+	}
+	return err
+}
+
+// Close writes the final block, the index and the footer. It does not close
+// the underlying writer.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+	footer := binary.BigEndian.AppendUint64(nil, uint64(w.offset))
+	footer = binary.BigEndian.AppendUint64(footer, uint64(len(w.index)))
+	footer = append(footer, Magic...)
+	if err := w.write(w.index); err != nil {
+		return err
+	}
+	if err := w.write(footer); err != nil {
+		return err
+	}
+	w.err = errors.New("sstable: writer closed")
+	return nil
+}
+
+type blockHandle struct {
+	first  []byte
+	offset int64
+	length int64
+}
+
+// Reader looks up entries in a table. It holds the index in memory and reads
+// data blocks on demand, so it is safe for concurrent use if r is.
+type Reader struct {
+	r      io.ReaderAt
+	blocks []blockHandle
+}
+
+// NewReader reads the footer and index of the table of the given size in r.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if size < int64(FooterSize) {
+		return nil, ErrCorrupt
+	}
+	footer := make([]byte, FooterSize)
+	if err := readAt(r, footer, size-int64(FooterSize)); err != nil {
+		return nil, err
+	}
+	if string(footer[16:]) != Magic {
+		return nil, ErrCorrupt
+	}
+	offset := binary.BigEndian.Uint64(footer)
+	length := binary.BigEndian.Uint64(footer[8:])
+	if offset > uint64(size)-uint64(FooterSize) || length != uint64(size)-uint64(FooterSize)-offset {
+		return nil, ErrCorrupt
+	}
+	index := make([]byte, length)
+	if err := readAt(r, index, int64(offset)); err != nil {
+		return nil, err
+	}
+	t := &Reader{r: r}
+	for i := 0; i < len(index); {
+		n, err := bone.Skip(index[i:])
+		if err != nil {
+			return nil, ErrCorrupt
+		}
+		h := blockHandle{first: index[i : i+n]}
+		i += n
+		if h.offset, n, err = bone.ReadInt(index[i:]); err != nil {
+			return nil, ErrCorrupt
+		}
+		i += n
+		if h.length, n, err = bone.ReadInt(index[i:]); err != nil {
+			return nil, ErrCorrupt
+		}
+		i += n
+		if h.offset < 0 || h.length <= 0 || h.offset+h.length > int64(offset) {
+			return nil, ErrCorrupt
+		}
+		t.blocks = append(t.blocks, h)
+	}
+	return t, nil
+}
+
+// find returns the index of the block that would hold key, or -1 if key
+// sorts before the first key of the table.
+func (t *Reader) find(key []byte) int {
+	i, found := slices.BinarySearchFunc(t.blocks, key, func(h blockHandle, key []byte) int {
+		return bytes.Compare(h.first, key)
+	})
+	if found {
+		return i
+	}
+	return i - 1
+}
+
+func (t *Reader) readBlock(i int) ([]byte, error) {
+	h := t.blocks[i]
+	block := make([]byte, h.length)
+	if err := readAt(t.r, block, h.offset); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// readAt is r.ReadAt without the io.EOF a ReaderAt may return alongside a
+// full read at the end of its input.
+func readAt(r io.ReaderAt, p []byte, off int64) error {
+	n, err := r.ReadAt(p, off)
+	if n == len(p) {
+		return nil
+	}
+	return err
+}
+
+// entry splits the entry at the start of block into its encoded key and
+// value.
+func entry(block []byte) (key, value []byte, err error) {
+	k, err := bone.Skip(block)
+	if err != nil {
+		return nil, nil, ErrCorrupt
+	}
+	v, err := bone.Skip(block[k:])
+	if err != nil {
+		return nil, nil, ErrCorrupt
+	}
+	return block[:k], block[k : k+v], nil
+}
+
+// Get returns the value stored under key. The second result reports whether
+// the key is present.
+func (t *Reader) Get(key *bone.Value) (*bone.Value, bool, error) {
+	k := bone.Encode([]*bone.Value{key})
+	i := t.find(k)
+	if i < 0 {
+		return nil, false, nil
+	}
+	block, err := t.readBlock(i)
+	if err != nil {
+		return nil, false, err
+	}
+	for len(block) > 0 {
+		ek, ev, err := entry(block)
+		if err != nil {
+			return nil, false, err
+		}
+		switch c := bytes.Compare(ek, k); {
+		case c == 0:
+			values, err := bone.Decode(ev)
+			if err != nil {
+				return nil, false, err
+			}
+			return values[0], true, nil
+		case c > 0:
+			return nil, false, nil
+		}
+		block = block[len(ek)+len(ev):]
+	}
+	return nil, false, nil
+}
+
+// Range returns an iterator over the entries with start <= key < end in key
+// order. A nil start or end leaves that side unbounded.
+func (t *Reader) Range(start, end *bone.Value) *Iterator {
+	it := &Iterator{t: t}
+	if start != nil {
+		it.start = bone.Encode([]*bone.Value{start})
+		it.next = max(t.find(it.start), 0)
+	}
+	if end != nil {
+		it.end = bone.Encode([]*bone.Value{end})
+	}
+	return it
+}
+
+// Iterator steps through a range of a table. Key and Value return the
+// encodings of the current entry; they are only valid until the next call to
+// Next.
+type Iterator struct {
+	t          *Reader
+	start, end []byte
+	next       int
+	block      []byte
+	key, value []byte
+	err        error
+	done       bool
+}
+
+// Next advances to the next entry, returning false at the end of the range
+// or on error.
+func (it *Iterator) Next() bool {
+	for !it.done {
+		if len(it.block) == 0 {
+			if it.next >= len(it.t.blocks) {
+				it.done = true
+				break
+			}
+			it.block, it.err = it.t.readBlock(it.next)
+			it.next++
+			if it.err != nil {
+				it.done = true
+				break
+			}
+		}
+		it.key, it.value, it.err = entry(it.block)
+		if it.err != nil {
+			it.done = true
+			break
+		}
+		it.block = it.block[len(it.key)+len(it.value):]
+		if it.start != nil && bytes.Compare(it.key, it.start) < 0 {
+			continue
+		}
+		if it.end != nil && bytes.Compare(it.key, it.end) >= 0 {
+			it.done = true
+			break
+		}
+		return true
+	}
+	it.key, it.value = nil, nil
+	return false
+}
+
+func (it *Iterator) Key() []byte {
+	return it.key
+}
+
+func (it *Iterator) Value() []byte {
+	return it.value
+}
+
+// Err returns the error, if any, that ended the iteration.
+func (it *Iterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,128 @@
+package sstable
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mrmcc3/bone-go"
+)
+
+func key(i int) *bone.Value {
+	return bone.Tuple(bone.Str("user"), bone.Int(int64(i)))
+}
+
+func buildTable(t *testing.T, n int) *Reader {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.BlockSize = 64
+	for i := 0; i < n; i++ {
+		if err := w.Add(key(i*2), bone.Int(int64(i))); err != nil {
+			t.Fatalf("Failed to add entry %d: %v", i, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Failed to open table: %v", err)
+	}
+	return r
+}
+
+func TestGet(t *testing.T) {
+	r := buildTable(t, 200)
+	if len(r.blocks) < 2 {
+		t.Fatalf("Expected several blocks, got %d", len(r.blocks))
+	}
+	for i := -1; i < 402; i++ {
+		v, ok, err := r.Get(key(i))
+		if err != nil {
+			t.Fatalf("Get %d: unexpected error: %v", i, err)
+		}
+		if want := i >= 0 && i < 400 && i%2 == 0; ok != want {
+			t.Fatalf("Get %d: expected found %v, got %v", i, want, ok)
+		}
+		if !ok {
+			continue
+		}
+		if x, _ := v.Int(); x != int64(i/2) {
+			t.Errorf("Get %d: expected %d, got %d", i, i/2, x)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	r := buildTable(t, 200)
+	cases := []struct {
+		start, end *bone.Value
+		first, n   int
+	}{
+		{nil, nil, 0, 200},
+		{key(101), key(151), 102, 25},
+		{key(100), key(150), 100, 25},
+		{nil, key(10), 0, 5},
+		{key(390), nil, 390, 5},
+		{key(500), nil, 0, 0},
+		{key(50), key(50), 0, 0},
+	}
+	for _, c := range cases {
+		it := r.Range(c.start, c.end)
+		n := 0
+		for it.Next() {
+			want := bone.Encode([]*bone.Value{key(c.first + 2*n)})
+			if !bytes.Equal(it.Key(), want) {
+				t.Fatalf("Range entry %d: expected key %x, got %x", n, want, it.Key())
+			}
+			n++
+		}
+		if it.Err() != nil {
+			t.Fatalf("Unexpected error: %v", it.Err())
+		}
+		if n != c.n {
+			t.Errorf("Expected %d entries, got %d", c.n, n)
+		}
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{})
+	if err := w.Add(key(2), bone.Null); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, k := range []int{2, 1} {
+		if err := w.Add(key(k), bone.Null); err != ErrOutOfOrder {
+			t.Errorf("Expected ErrOutOfOrder for key %d, got %v", k, err)
+		}
+	}
+	if err := w.Add(key(3), bone.Null); err != nil {
+		t.Errorf("Unexpected error after rejected key: %v", err)
+	}
+}
+
+func TestEmptyAndCorrupt(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Failed to open empty table: %v", err)
+	}
+	if _, ok, err := r.Get(key(0)); ok || err != nil {
+		t.Errorf("Expected no entry in empty table, got %v %v", ok, err)
+	}
+	if r.Range(nil, nil).Next() {
+		t.Error("Expected empty range")
+	}
+
+	data := bytes.Clone(buf.Bytes())
+	data[len(data)-1] ^= 0xFF
+	if _, err := NewReader(bytes.NewReader(data), int64(len(data))); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Expected ErrCorrupt for bad magic, got %v", err)
+	}
+	if _, err := NewReader(bytes.NewReader(data[:4]), 4); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Expected ErrCorrupt for short input, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+//go:build ignore
+
+// gen writes the benchmark inputs to testdata/<shape>.bone so other tools can
+// be measured against the same bytes the benchmarks use.
+//
+//	go run testdata/gen.go -n 100000
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/mrmcc3/bone-go"
+	"github.com/mrmcc3/bone-go/internal/benchdata"
+)
+
+func main() {
+	n := flag.Int("n", 10000, "values per shape")
+	dir := flag.String("dir", "testdata", "output directory")
+	flag.Parse()
+	for _, shape := range benchdata.Shapes {
+		path := filepath.Join(*dir, shape.Name+".bone")
+		if err := os.WriteFile(path, bone.Encode(shape.Generate(*n)), 0o644); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("wrote %s", path)
+	}
+}
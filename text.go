@@ -0,0 +1,27 @@
+package bone
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+// NewBytes returns an opaque byte string (code 0x90).
+func NewBytes(b []byte) *Value {
+	return &Value{Code: CodeBytes, Bytes: b}
+}
+
+// NewText returns a UTF-8 text string (code 0x91). Other string codes remain
+// opaque bytes.
+func NewText(s string) *Value {
+	return &Value{Code: CodeText, Bytes: []byte(s)}
+}
+
+func (v *Value) Text() (string, error) {
+	if v.Code != CodeText {
+		return "", errors.New("not text")
+	}
+	if !utf8.Valid(v.Bytes) {
+		return "", errors.New("invalid utf-8 text")
+	}
+	return string(v.Bytes), nil
+}
@@ -0,0 +1,46 @@
+package bone
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	payload := Encode([]*Value{NewText("héllo\x00wörld"), NewBytes([]byte{0xFF, 0x00, 0xFE})})
+	values, err := DecodeStrict(payload)
+	if err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	s, err := values[0].Text()
+	if err != nil {
+		t.Fatalf("Failed to read text: %v", err)
+	}
+	if s != "héllo\x00wörld" {
+		t.Errorf("Expected %q, got %q", "héllo\x00wörld", s)
+	}
+	if _, err := values[1].Text(); err == nil {
+		t.Errorf("Expected error reading raw bytes as text")
+	}
+	if !bytes.Equal(values[1].Bytes, []byte{0xFF, 0x00, 0xFE}) {
+		t.Errorf("Expected raw bytes FF00FE, got %X", values[1].Bytes)
+	}
+}
+
+func TestDecodeStrictText(t *testing.T) {
+	illegal := [][]byte{
+		{0x91, 0xFF, 0x00},
+		{0x91, 0xC3, 0x00},
+		{0xF0, 0xFF, 0x91, 0x41, 0xE2, 0x82, 0x00, 0x00},
+	}
+	for _, payload := range illegal {
+		if _, err := Decode(payload); err != nil {
+			t.Errorf("Unexpected error for % X in lenient mode: %v", payload, err)
+		}
+		if _, err := DecodeStrict(payload); err == nil {
+			t.Errorf("Expected error for invalid utf-8 in % X", payload)
+		}
+	}
+	if _, err := DecodeStrict([]byte{0x90, 0xFF, 0x00}); err != nil {
+		t.Errorf("Unexpected error for raw bytes in strict mode: %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package bone
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Codec is implemented by types that convert to and from a Value. The
+// element types of Tuple2 through Tuple5 must implement it, which they do
+// themselves so tuples nest.
+type Codec[T any] interface {
+	EncodeValue() *Value
+	DecodeValue(*Value) (T, error)
+}
+
+type Int64 int64
+
+func (i Int64) EncodeValue() *Value { return NewInt(int64(i)) }
+
+func (Int64) DecodeValue(v *Value) (Int64, error) {
+	i, err := v.Int()
+	return Int64(i), err
+}
+
+type Float64 float64
+
+func (f Float64) EncodeValue() *Value { return NewFloat(float64(f)) }
+
+func (Float64) DecodeValue(v *Value) (Float64, error) {
+	f, err := v.Float()
+	return Float64(f), err
+}
+
+type Boolean bool
+
+func (b Boolean) EncodeValue() *Value {
+	if b {
+		return &Value{Code: CodeTrue}
+	}
+	return &Value{Code: CodeFalse}
+}
+
+func (Boolean) DecodeValue(v *Value) (Boolean, error) {
+	switch {
+	case v.Code == CodeTrue && v.Level == 0:
+		return true, nil
+	case v.Code == CodeFalse && v.Level == 0:
+		return false, nil
+	}
+	return false, errors.New("not a bool")
+}
+
+type Text string
+
+func (s Text) EncodeValue() *Value { return NewText(string(s)) }
+
+func (Text) DecodeValue(v *Value) (Text, error) {
+	s, err := v.Text()
+	return Text(s), err
+}
+
+type Bytes []byte
+
+func (b Bytes) EncodeValue() *Value { return NewBytes(b) }
+
+func (Bytes) DecodeValue(v *Value) (Bytes, error) {
+	if v.Code != CodeBytes {
+		return nil, errors.New("not bytes")
+	}
+	return Bytes(v.Bytes), nil
+}
+
+func tupleValues(v *Value, code byte) ([]*Value, error) {
+	if v.Code != code || v.Level != 0 || len(v.Values) != int(code-0xA0)/0x10+1 {
+		return nil, fmt.Errorf("expected tuple code 0x%02X, got 0x%02X", code, v.Code)
+	}
+	return v.Values, nil
+}
+
+type Tuple2[A Codec[A], B Codec[B]] struct {
+	V1 A
+	V2 B
+}
+
+func (t Tuple2[A, B]) Encode() *Value {
+	return &Value{Code: 0xB0, Values: []*Value{t.V1.EncodeValue(), t.V2.EncodeValue()}}
+}
+
+func (t *Tuple2[A, B]) Decode(v *Value) error {
+	values, err := tupleValues(v, 0xB0)
+	if err != nil {
+		return err
+	}
+	if t.V1, err = t.V1.DecodeValue(values[0]); err != nil {
+		return err
+	}
+	t.V2, err = t.V2.DecodeValue(values[1])
+	return err
+}
+
+func (t Tuple2[A, B]) EncodeValue() *Value { return t.Encode() }
+
+func (t Tuple2[A, B]) DecodeValue(v *Value) (Tuple2[A, B], error) {
+	err := t.Decode(v)
+	return t, err
+}
+
+type Tuple3[A Codec[A], B Codec[B], C Codec[C]] struct {
+	V1 A
+	V2 B
+	V3 C
+}
+
+func (t Tuple3[A, B, C]) Encode() *Value {
+	return &Value{Code: 0xC0, Values: []*Value{t.V1.EncodeValue(), t.V2.EncodeValue(), t.V3.EncodeValue()}}
+}
+
+func (t *Tuple3[A, B, C]) Decode(v *Value) error {
+	values, err := tupleValues(v, 0xC0)
+	if err != nil {
+		return err
+	}
+	if t.V1, err = t.V1.DecodeValue(values[0]); err != nil {
+		return err
+	}
+	if t.V2, err = t.V2.DecodeValue(values[1]); err != nil {
+		return err
+	}
+	t.V3, err = t.V3.DecodeValue(values[2])
+	return err
+}
+
+func (t Tuple3[A, B, C]) EncodeValue() *Value { return t.Encode() }
+
+func (t Tuple3[A, B, C]) DecodeValue(v *Value) (Tuple3[A, B, C], error) {
+	err := t.Decode(v)
+	return t, err
+}
+
+type Tuple4[A Codec[A], B Codec[B], C Codec[C], D Codec[D]] struct {
+	V1 A
+	V2 B
+	V3 C
+	V4 D
+}
+
+func (t Tuple4[A, B, C, D]) Encode() *Value {
+	return &Value{Code: 0xD0, Values: []*Value{t.V1.EncodeValue(), t.V2.EncodeValue(), t.V3.EncodeValue(), t.V4.EncodeValue()}}
+}
+
+func (t *Tuple4[A, B, C, D]) Decode(v *Value) error {
+	values, err := tupleValues(v, 0xD0)
+	if err != nil {
+		return err
+	}
+	if t.V1, err = t.V1.DecodeValue(values[0]); err != nil {
+		return err
+	}
+	if t.V2, err = t.V2.DecodeValue(values[1]); err != nil {
+		return err
+	}
+	if t.V3, err = t.V3.DecodeValue(values[2]); err != nil {
+		return err
+	}
+	t.V4, err = t.V4.DecodeValue(values[3])
+	return err
+}
+
+func (t Tuple4[A, B, C, D]) EncodeValue() *Value { return t.Encode() }
+
+func (t Tuple4[A, B, C, D]) DecodeValue(v *Value) (Tuple4[A, B, C, D], error) {
+	err := t.Decode(v)
+	return t, err
+}
+
+type Tuple5[A Codec[A], B Codec[B], C Codec[C], D Codec[D], E Codec[E]] struct {
+	V1 A
+	V2 B
+	V3 C
+	V4 D
+	V5 E
+}
+
+func (t Tuple5[A, B, C, D, E]) Encode() *Value {
+	return &Value{Code: 0xE0, Values: []*Value{t.V1.EncodeValue(), t.V2.EncodeValue(), t.V3.EncodeValue(), t.V4.EncodeValue(), t.V5.EncodeValue()}}
+}
+
+func (t *Tuple5[A, B, C, D, E]) Decode(v *Value) error {
+	values, err := tupleValues(v, 0xE0)
+	if err != nil {
+		return err
+	}
+	if t.V1, err = t.V1.DecodeValue(values[0]); err != nil {
+		return err
+	}
+	if t.V2, err = t.V2.DecodeValue(values[1]); err != nil {
+		return err
+	}
+	if t.V3, err = t.V3.DecodeValue(values[2]); err != nil {
+		return err
+	}
+	if t.V4, err = t.V4.DecodeValue(values[3]); err != nil {
+		return err
+	}
+	t.V5, err = t.V5.DecodeValue(values[4])
+	return err
+}
+
+func (t Tuple5[A, B, C, D, E]) EncodeValue() *Value { return t.Encode() }
+
+func (t Tuple5[A, B, C, D, E]) DecodeValue(v *Value) (Tuple5[A, B, C, D, E], error) {
+	err := t.Decode(v)
+	return t, err
+}
@@ -0,0 +1,83 @@
+package bone
+
+import (
+	"bytes"
+	"testing"
+)
+
+type userKey = Tuple3[Text, Int64, Int64]
+
+func TestTupleRoundTrip(t *testing.T) {
+	key := userKey{V1: "acme", V2: 42, V3: 1_700_000_000}
+	payload := Encode([]*Value{key.Encode()})
+	expected := []byte{
+		0xC0,
+		0x91, 'a', 'c', 'm', 'e', 0x00,
+		0x18, 0x2A,
+		0x1B, 0x65, 0x53, 0xF1, 0x00,
+	}
+	if !bytes.Equal(payload, expected) {
+		t.Fatalf("Expected % X, got % X", expected, payload)
+	}
+	values, err := Decode(payload)
+	if err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	var got userKey
+	if err := got.Decode(values[0]); err != nil {
+		t.Fatalf("Failed to decode tuple: %v", err)
+	}
+	if got != key {
+		t.Errorf("Expected %+v, got %+v", key, got)
+	}
+
+	nested := Tuple5[Boolean, Float64, Bytes, Tuple2[Int64, Text], userKey]{
+		V1: true,
+		V2: -2.5,
+		V3: Bytes{0x00, 0x01},
+		V4: Tuple2[Int64, Text]{V1: -1, V2: "x"},
+		V5: key,
+	}
+	values, err = Decode(Encode([]*Value{nested.Encode()}))
+	if err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	var gotNested Tuple5[Boolean, Float64, Bytes, Tuple2[Int64, Text], userKey]
+	if err := gotNested.Decode(values[0]); err != nil {
+		t.Fatalf("Failed to decode nested tuple: %v", err)
+	}
+	if gotNested.V1 != nested.V1 || gotNested.V2 != nested.V2 || !bytes.Equal(gotNested.V3, nested.V3) ||
+		gotNested.V4 != nested.V4 || gotNested.V5 != nested.V5 {
+		t.Errorf("Expected %+v, got %+v", nested, gotNested)
+	}
+}
+
+func TestTupleOrdering(t *testing.T) {
+	keys := []userKey{
+		{V1: "acme", V2: -5, V3: 0},
+		{V1: "acme", V2: 7, V3: 2},
+		{V1: "acme", V2: 7, V3: 10},
+		{V1: "acme", V2: 1000, V3: 0},
+		{V1: "beta", V2: 0, V3: 0},
+	}
+	for i := 1; i < len(keys); i++ {
+		if Compare(keys[i-1].Encode(), keys[i].Encode()) >= 0 {
+			t.Errorf("Expected %+v to sort before %+v", keys[i-1], keys[i])
+		}
+	}
+}
+
+func TestTupleDecodeErrors(t *testing.T) {
+	var key userKey
+	for _, v := range []*Value{
+		NewInt(1),
+		{Code: 0xC1, Values: []*Value{NewText("a"), NewInt(1), NewInt(2)}},
+		{Code: 0xC0, Level: 1, Values: []*Value{NewText("a"), NewInt(1), NewInt(2)}},
+		{Code: 0xC0, Values: []*Value{NewInt(1), NewInt(1), NewInt(2)}},
+		{Code: 0xC0, Values: []*Value{NewText("a"), NewText("b"), NewInt(2)}},
+	} {
+		if err := key.Decode(v); err == nil {
+			t.Errorf("Expected error decoding code 0x%02X", v.Code)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package bone
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// Writer streams BONE values to an io.Writer. Lists and tuples may be opened
+// and filled incrementally, so an unbounded list never has to be held in
+// memory. Tuples close themselves once they hold their fixed number of
+// values; lists are closed with EndList.
+type Writer struct {
+	w     *bufio.Writer
+	buf   []byte
+	stack []writerFrame
+	err   error
+}
+
+type writerFrame struct {
+	code      byte
+	remaining int
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+func (w *Writer) BeginList(code byte, level int) error {
+	if code < 0xF0 || code == 0xFF {
+		return errors.New("not a list type code")
+	}
+	return w.begin(code, level, -1)
+}
+
+func (w *Writer) BeginTuple(code byte, level int) error {
+	if code < 0xA0 || code >= 0xF0 {
+		return errors.New("not a tuple type code")
+	}
+	return w.begin(code, level, int(code-0xA0)/0x10+1)
+}
+
+func (w *Writer) begin(code byte, level int, remaining int) error {
+	if level < 0 {
+		return errors.New("negative level")
+	}
+	w.buf = w.buf[:0]
+	for range level {
+		w.buf = append(w.buf, 0xFF)
+	}
+	w.buf = append(w.buf, code)
+	if err := w.write(w.buf); err != nil {
+		return err
+	}
+	w.stack = append(w.stack, writerFrame{code: code, remaining: remaining})
+	return nil
+}
+
+func (w *Writer) Value(v *Value) error {
+	if err := validate(v); err != nil {
+		return err
+	}
+	w.buf = AppendEncode(w.buf[:0], v)
+	if err := w.write(w.buf); err != nil {
+		return err
+	}
+	w.completed()
+	return nil
+}
+
+// validate walks v and reports anything that would encode to bytes Decode
+// rejects: illegal codes or levels, blocks of the wrong width, tuples of the
+// wrong arity and children on blocks or strings.
+func validate(v *Value) error {
+	info := codeTable[v.Code]
+	switch {
+	case info.kind == kindIllegal || info.kind == kindLevel:
+		return errors.New("illegal type code")
+	case v.Level < 0:
+		return errors.New("negative level")
+	case v.Level > 0 && v.Code < 0x20:
+		return errors.New("illegal level extension")
+	case info.kind == kindBlock && len(v.Bytes) != int(info.width):
+		return errors.New("block has wrong width")
+	case info.kind == kindTuple && len(v.Values) != int(info.arity):
+		return errors.New("tuple has wrong arity")
+	case (info.kind == kindBlock || info.kind == kindString) && len(v.Values) != 0:
+		return errors.New("block or string with values")
+	}
+	for _, c := range v.Values {
+		if err := validate(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Writer) EndList() error {
+	l := len(w.stack)
+	if l == 0 {
+		return errors.New("no open list")
+	}
+	if w.stack[l-1].remaining >= 0 {
+		return errors.New("tuple is incomplete")
+	}
+	if err := w.write([]byte{0x00}); err != nil {
+		return err
+	}
+	w.stack = w.stack[:l-1]
+	w.completed()
+	return nil
+}
+
+func (w *Writer) completed() {
+	for l := len(w.stack); l > 0; l-- {
+		f := &w.stack[l-1]
+		if f.remaining < 0 {
+			return
+		}
+		f.remaining--
+		if f.remaining > 0 {
+			return
+		}
+		w.stack = w.stack[:l-1]
+	}
+}
+
+func (w *Writer) write(b []byte) error {
+	if w.err != nil {
+		return w.err
+	}
+	_, w.err = w.w.Write(b)
+	return w.err
+}
+
+// Depth reports how many lists and tuples are currently open.
+func (w *Writer) Depth() int {
+	return len(w.stack)
+}
+
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.err = w.w.Flush()
+	return w.err
+}
+
+// Close flushes buffered output and reports an error if any list or tuple is
+// still open. It does not close the underlying io.Writer.
+func (w *Writer) Close() error {
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(w.stack) != 0 {
+		return errors.New("unterminated list or tuple")
+	}
+	return nil
+}
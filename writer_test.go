@@ -0,0 +1,123 @@
+package bone
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	steps := []func() error{
+		func() error { return w.BeginList(0xF0, 0) },
+		func() error { return w.Value(NewText("ABC")) },
+		func() error { return w.BeginTuple(0xB1, 0) },
+		func() error { return w.Value(NewInt(-86)) },
+		func() error { return w.BeginList(0xFE, 2) },
+		func() error { return w.Value(&Value{Code: 0x21, Level: 1}) },
+		func() error { return w.EndList() },
+		func() error { return w.BeginTuple(0xA0, 1) },
+		func() error { return w.Value(NewInt(1)) },
+		func() error { return w.EndList() },
+		func() error { return w.Value(NewInt(300)) },
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("Step %d: unexpected error: %v", i, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+	expected := Encode([]*Value{
+		{Code: 0xF0, Values: []*Value{
+			NewText("ABC"),
+			{Code: 0xB1, Values: []*Value{
+				NewInt(-86),
+				{Code: 0xFE, Level: 2, Values: []*Value{{Code: 0x21, Level: 1}}},
+			}},
+			{Code: 0xA0, Level: 1, Values: []*Value{NewInt(1)}},
+		}},
+		NewInt(300),
+	})
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("Expected % X, got % X", expected, buf.Bytes())
+	}
+}
+
+func TestWriterMisuse(t *testing.T) {
+	cases := map[string]func(w *Writer) error{
+		"end without list": func(w *Writer) error { return w.EndList() },
+		"end open tuple": func(w *Writer) error {
+			w.BeginTuple(0xB0, 0)
+			w.Value(NewInt(1))
+			return w.EndList()
+		},
+		"tuple code as list": func(w *Writer) error { return w.BeginList(0xB0, 0) },
+		"list code as tuple": func(w *Writer) error { return w.BeginTuple(0xF0, 0) },
+		"negative level":     func(w *Writer) error { return w.BeginList(0xF0, -1) },
+		"illegal code":       func(w *Writer) error { return w.Value(&Value{Code: 0x01}) },
+		"illegal level":      func(w *Writer) error { return w.Value(&Value{Code: 0x10, Level: 1}) },
+		"short block":        func(w *Writer) error { return w.Value(&Value{Code: 0x40, Bytes: []byte{1}}) },
+		"empty tuple":        func(w *Writer) error { return w.Value(&Value{Code: 0xB0}) },
+		"illegal child code": func(w *Writer) error { return w.Value(List(Int(1), &Value{Code: 0x02})) },
+		"illegal child level": func(w *Writer) error {
+			return w.Value(Tuple(&Value{Code: 0x18, Level: 2, Bytes: []byte{9}}))
+		},
+		"string with values": func(w *Writer) error { return w.Value(&Value{Code: 0x91, Values: []*Value{Null}}) },
+		"level code":         func(w *Writer) error { return w.Value(&Value{Code: 0xFF}) },
+		"close open list": func(w *Writer) error {
+			w.BeginList(0xF0, 0)
+			return w.Close()
+		},
+	}
+	for name, misuse := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := misuse(NewWriter(&bytes.Buffer{})); err == nil {
+				t.Errorf("Expected error")
+			}
+		})
+	}
+}
+
+func TestWriterRejectsBeforeWriting(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.Value(&Value{Code: 0xB0}); err == nil {
+		t.Error("Expected error for incomplete tuple")
+	}
+	if err := w.Value(Block(0x40, 1, 2)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+	if _, err := Decode(buf.Bytes()); err != nil {
+		t.Errorf("Expected decodable output, got %v for % X", err, buf.Bytes())
+	}
+}
+
+func TestWriterStreamsLargeList(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.BeginList(0xF0, 0)
+	for i := range 100_000 {
+		if err := w.Value(NewInt(int64(i))); err != nil {
+			t.Fatalf("Failed to write value %d: %v", i, err)
+		}
+	}
+	w.EndList()
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+	values, err := Decode(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	if len(values) != 1 || len(values[0].Values) != 100_000 {
+		t.Fatalf("Expected one list of 100000 values")
+	}
+	if w.Depth() != 0 {
+		t.Errorf("Expected depth 0, got %d", w.Depth())
+	}
+}